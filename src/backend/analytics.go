@@ -0,0 +1,298 @@
+// src/backend/analytics.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultAnalyticsLimit = 50
+
+type ElementAnalytics struct {
+	Element               string  `json:"element"`
+	Tier                  int     `json:"tier"`
+	RecipeUses            int     `json:"recipeUses"`
+	RecipesProducing      int     `json:"recipesProducing"`
+	TransitiveDependents  int     `json:"transitiveDependents"`
+	IsCutElement          bool    `json:"isCutElement"`
+	UnreachableWithout    int     `json:"unreachableWithout"`
+	Betweenness           float64 `json:"betweenness"`
+	NormalizedBetweenness float64 `json:"normalizedBetweenness"`
+}
+
+type AnalyticsResponse struct {
+	DatasetVersion string             `json:"datasetVersion"`
+	ElementCount   int                `json:"elementCount"`
+	SortBy         string             `json:"sortBy"`
+	ComputedMillis int64              `json:"computedMillis"`
+	Results        []ElementAnalytics `json:"results"`
+}
+
+var analyticsSortKeys = map[string]func(a, b ElementAnalytics) bool{
+	"betweenness": func(a, b ElementAnalytics) bool { return a.Betweenness > b.Betweenness },
+	"uses":        func(a, b ElementAnalytics) bool { return a.RecipeUses > b.RecipeUses },
+	"dependents":  func(a, b ElementAnalytics) bool { return a.TransitiveDependents > b.TransitiveDependents },
+	"cut":         func(a, b ElementAnalytics) bool { return a.UnreachableWithout > b.UnreachableWithout },
+}
+
+// Hasil analitik hanya bergantung pada dataset, jadi disimpan per versi dataset.
+var analyticsCache struct {
+	sync.Mutex
+	version  string
+	results  []ElementAnalytics
+	duration time.Duration
+}
+
+// reachableElements menghitung elemen yang bisa dibuat dari elemen awal tanpa memakai elemen excluded.
+// Setiap resep menghitung bahannya yang sudah tersedia, sehingga biayanya linear terhadap jumlah resep.
+func reachableElements(recipes []Recipe, usesByIngredient map[string][]int, start []string, excluded string) map[string]bool {
+	reachable := make(map[string]bool)
+	satisfied := make([]int, len(recipes))
+	queue := make([]string, 0, len(start))
+	for _, element := range start {
+		if element != excluded && !reachable[element] {
+			reachable[element] = true
+			queue = append(queue, element)
+		}
+	}
+	for len(queue) > 0 {
+		element := queue[0]
+		queue = queue[1:]
+		for _, index := range usesByIngredient[element] {
+			recipe := recipes[index]
+			satisfied[index]++
+			needed := 2
+			if recipe.Ingredient1 == recipe.Ingredient2 {
+				needed = 1
+			}
+			if satisfied[index] == needed && recipe.Result != excluded && !reachable[recipe.Result] {
+				reachable[recipe.Result] = true
+				queue = append(queue, recipe.Result)
+			}
+		}
+	}
+	return reachable
+}
+
+// indexRecipeUses memetakan setiap bahan ke indeks resep yang memakainya (sekali per resep).
+func indexRecipeUses(recipes []Recipe) map[string][]int {
+	uses := make(map[string][]int)
+	for i, recipe := range recipes {
+		uses[recipe.Ingredient1] = append(uses[recipe.Ingredient1], i)
+		if recipe.Ingredient2 != recipe.Ingredient1 {
+			uses[recipe.Ingredient2] = append(uses[recipe.Ingredient2], i)
+		}
+	}
+	return uses
+}
+
+// computeElementAnalytics menghitung seluruh metrik untuk setiap elemen pada dataset yang diberikan.
+func computeElementAnalytics(recipesByResult map[string][]Recipe, elementNames map[string]bool) []ElementAnalytics {
+	recipes := flattenRecipeMap(recipesByResult)
+	usesByIngredient := indexRecipeUses(recipes)
+	tiers, _ := calculateElementTiers(recipes, baseElements)
+
+	elements := make([]string, 0, len(elementNames))
+	for name := range elementNames {
+		elements = append(elements, name)
+	}
+	sort.Strings(elements)
+
+	// Graf "dipakai untuk membuat": bahan -> hasil.
+	successors := make(map[string][]string)
+	seenEdge := make(map[[2]string]bool)
+	for _, recipe := range recipes {
+		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+			edge := [2]string{ingredient, recipe.Result}
+			if !seenEdge[edge] {
+				seenEdge[edge] = true
+				successors[ingredient] = append(successors[ingredient], recipe.Result)
+			}
+		}
+	}
+
+	baseline := reachableElements(recipes, usesByIngredient, baseElements, "")
+	betweenness := tierDAGBetweenness(elements, recipes, tiers)
+	n := float64(len(elements))
+	normalizer := (n - 1) * (n - 2)
+
+	results := make([]ElementAnalytics, 0, len(elements))
+	for _, element := range elements {
+		tier, ok := tiers[element]
+		if !ok {
+			tier = -1
+		}
+		entry := ElementAnalytics{
+			Element:          element,
+			Tier:             tier,
+			RecipeUses:       len(usesByIngredient[element]),
+			RecipesProducing: len(recipesByResult[element]),
+			Betweenness:      betweenness[element],
+		}
+		if normalizer > 0 {
+			entry.NormalizedBetweenness = math.Round(betweenness[element]/normalizer*1e6) / 1e6
+		}
+
+		visited := map[string]bool{element: true}
+		queue := []string{element}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, next := range successors[current] {
+				if !visited[next] {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+		entry.TransitiveDependents = len(visited) - 1
+
+		if baseline[element] {
+			without := reachableElements(recipes, usesByIngredient, baseElements, element)
+			entry.UnreachableWithout = len(baseline) - len(without) - 1
+			entry.IsCutElement = entry.UnreachableWithout > 0
+		}
+		results = append(results, entry)
+	}
+	return results
+}
+
+// tierDAGBetweenness menjalankan Brandes pada DAG derivasi: hanya sisi bahan -> hasil yang menaikkan tier,
+// sehingga lintasan terpendek mengikuti cara tercepat membuat setiap elemen.
+func tierDAGBetweenness(elements []string, recipes []Recipe, tiers map[string]int) map[string]float64 {
+	successors := make(map[string][]string)
+	seenEdge := make(map[[2]string]bool)
+	for _, recipe := range recipes {
+		resultTier, ok := tiers[recipe.Result]
+		if !ok {
+			continue
+		}
+		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+			ingredientTier, ok := tiers[ingredient]
+			edge := [2]string{ingredient, recipe.Result}
+			if ok && ingredientTier < resultTier && !seenEdge[edge] {
+				seenEdge[edge] = true
+				successors[ingredient] = append(successors[ingredient], recipe.Result)
+			}
+		}
+	}
+	for _, next := range successors {
+		sort.Strings(next)
+	}
+
+	centrality := make(map[string]float64, len(elements))
+	for _, source := range elements {
+		stack := []string{}
+		predecessors := make(map[string][]string)
+		sigma := map[string]float64{source: 1}
+		distance := map[string]int{source: 0}
+		queue := []string{source}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			stack = append(stack, v)
+			for _, w := range successors[v] {
+				if _, seen := distance[w]; !seen {
+					distance[w] = distance[v] + 1
+					queue = append(queue, w)
+				}
+				if distance[w] == distance[v]+1 {
+					sigma[w] += sigma[v]
+					predecessors[w] = append(predecessors[w], v)
+				}
+			}
+		}
+
+		delta := make(map[string]float64, len(stack))
+		for i := len(stack) - 1; i >= 0; i-- {
+			w := stack[i]
+			for _, v := range predecessors[w] {
+				delta[v] += sigma[v] / sigma[w] * (1 + delta[w])
+			}
+			if w != source {
+				centrality[w] += delta[w]
+			}
+		}
+	}
+	return centrality
+}
+
+// GetElementAnalytics mengembalikan analitik dari cache selama versi dataset tidak berubah. Map, nama
+// elemen, dan versi diambil dari satu snapshot agar analitik selalu disimpan di bawah versi yang benar.
+func GetElementAnalytics() ([]ElementAnalytics, time.Duration, error) {
+	dataset := getDatasetState()
+	if dataset.recipeMap == nil {
+		return nil, 0, errors.New("map resep belum diinisialisasi")
+	}
+	version := dataset.version
+
+	analyticsCache.Lock()
+	defer analyticsCache.Unlock()
+	if analyticsCache.results != nil && analyticsCache.version == version {
+		return analyticsCache.results, analyticsCache.duration, nil
+	}
+
+	start := time.Now()
+	results := computeElementAnalytics(dataset.recipeMap, dataset.allElementNames)
+	analyticsCache.version = version
+	analyticsCache.results = results
+	analyticsCache.duration = time.Since(start)
+	log.Printf("Analitik graf dihitung untuk dataset %s dalam %v\n", version, analyticsCache.duration)
+	return results, analyticsCache.duration, nil
+}
+
+func analyticsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sortBy := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("sort")))
+	if sortBy == "" {
+		sortBy = "betweenness"
+	}
+	less, ok := analyticsSortKeys[sortBy]
+	if !ok {
+		http.Error(w, "Parameter 'sort' harus 'betweenness', 'uses', 'dependents', atau 'cut'", http.StatusBadRequest)
+		return
+	}
+	limit := defaultAnalyticsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			http.Error(w, "Parameter 'limit' harus berupa angka positif", http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, duration, err := GetElementAnalytics()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Gagal menghitung analitik: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	ranked := append([]ElementAnalytics(nil), results...)
+	sort.SliceStable(ranked, func(i, j int) bool { return less(ranked[i], ranked[j]) })
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	writeJSON(w, AnalyticsResponse{
+		DatasetVersion: GetDatasetVersion(),
+		ElementCount:   len(results),
+		SortBy:         sortBy,
+		ComputedMillis: duration.Milliseconds(),
+		Results:        ranked,
+	})
+}
@@ -0,0 +1,62 @@
+// src/backend/analytics_test.go
+package main
+
+import "testing"
+
+func TestCutElementsMatchFilterUnmakeable(t *testing.T) {
+	loadTestDataset(t)
+
+	results, _, err := GetElementAnalytics()
+	if err != nil {
+		t.Fatal(err)
+	}
+	byElement := make(map[string]ElementAnalytics, len(results))
+	for _, entry := range results {
+		byElement[entry.Element] = entry
+	}
+
+	all := flattenRecipeMap(GetRecipeMap())
+	makeable := func(recipes []Recipe) int {
+		kept, _ := filterUnmakeablePaths(recipes, baseElements)
+		results := make(map[string]bool)
+		for _, recipe := range kept {
+			results[recipe.Result] = true
+		}
+		return len(results)
+	}
+	before := makeable(all)
+	for _, element := range []string{"Lake", "Human", "Brick", "Mud"} {
+		var without []Recipe
+		for _, recipe := range all {
+			if recipe.Result != element && recipe.Ingredient1 != element && recipe.Ingredient2 != element {
+				without = append(without, recipe)
+			}
+		}
+		want := before - makeable(without) - 1
+		if got := byElement[element].UnreachableWithout; got != want {
+			t.Errorf("%s: %d elemen tidak terjangkau, filterUnmakeablePaths memberi %d", element, got, want)
+		}
+		if byElement[element].IsCutElement != (want > 0) {
+			t.Errorf("%s: status cut element salah", element)
+		}
+	}
+
+	cached, _, _ := GetElementAnalytics()
+	if &cached[0] != &results[0] {
+		t.Error("analitik seharusnya diambil dari cache untuk versi dataset yang sama")
+	}
+}
+
+func TestTierDAGBetweennessChain(t *testing.T) {
+	recipes := []Recipe{
+		{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+		{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"},
+		{Result: "Wall", Ingredient1: "Brick", Ingredient2: "Brick"},
+	}
+	tiers, _ := calculateElementTiers(recipes, baseElements)
+	centrality := tierDAGBetweenness([]string{"Brick", "Earth", "Fire", "Mud", "Wall", "Water"}, recipes, tiers)
+	// Mud dilewati oleh Water->Brick, Water->Wall, Earth->Brick, Earth->Wall; Brick oleh Mud/Water/Earth/Fire->Wall.
+	if centrality["Mud"] != 4 || centrality["Brick"] != 4 || centrality["Wall"] != 0 {
+		t.Errorf("betweenness tidak sesuai: %v", centrality)
+	}
+}
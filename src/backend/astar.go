@@ -0,0 +1,180 @@
+// src/backend/astar.go
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+func init() {
+	RegisterSearcher(funcSearcher{
+		name:         "astar",
+		description:  "A* dari elemen dasar dengan heuristik jarak ke target dan batas tier; kedalaman sama dengan BFS",
+		capabilities: SearchCapabilities{Modes: []string{"shortest"}, Deterministic: true},
+		shortest:     FindPathAStar,
+	})
+}
+
+// astarHeuristics menyimpan data yang hanya bergantung pada dataset, dihitung sekali per versi dataset.
+type astarHeuristics struct {
+	tiers    map[string]int
+	minSizes map[string]int
+}
+
+var astarCache struct {
+	sync.Mutex
+	version    string
+	heuristics *astarHeuristics
+}
+
+// getAStarHeuristics memakai versi dari snapshot yang sama dengan map resep state.
+func getAStarHeuristics(state datasetState) *astarHeuristics {
+	astarCache.Lock()
+	defer astarCache.Unlock()
+	if astarCache.heuristics != nil && astarCache.version == state.version {
+		return astarCache.heuristics
+	}
+	minSizes := calculateMinTreeSizes(state.recipeMap)
+	tiers, _ := calculateElementTiers(flattenRecipeMap(state.recipeMap), baseElements)
+	astarCache.version = state.version
+	astarCache.heuristics = &astarHeuristics{tiers: tiers, minSizes: minSizes}
+	return astarCache.heuristics
+}
+
+// distanceToTarget menghitung jumlah langkah resep minimum dari setiap elemen ke target (BFS mundur dari
+// target ke bahan-bahannya). Elemen tanpa entri tidak pernah dipakai untuk membuat target.
+func distanceToTarget(target string, recipesByResult map[string][]Recipe) map[string]int {
+	distance := map[string]int{target: 0}
+	queue := []string{target}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if isBaseElement(current) {
+			continue
+		}
+		for _, recipe := range recipesByResult[current] {
+			for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+				if _, seen := distance[ingredient]; !seen {
+					distance[ingredient] = distance[current] + 1
+					queue = append(queue, ingredient)
+				}
+			}
+		}
+	}
+	return distance
+}
+
+type astarEntry struct {
+	element string
+	g       int
+	f       int
+	cost    int
+}
+
+type astarQueue []astarEntry
+
+func (q astarQueue) Len() int { return len(q) }
+func (q astarQueue) Less(i, j int) bool {
+	if q[i].f != q[j].f {
+		return q[i].f < q[j].f
+	}
+	// Pada f yang sama, elemen yang lebih dalam lebih dekat ke target.
+	if q[i].g != q[j].g {
+		return q[i].g > q[j].g
+	}
+	if q[i].cost != q[j].cost {
+		return q[i].cost < q[j].cost
+	}
+	return q[i].element < q[j].element
+}
+func (q astarQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *astarQueue) Push(x any)   { *q = append(*q, x.(astarEntry)) }
+func (q *astarQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// FindPathAStar mencari rencana dengan kedalaman minimum seperti FindPathBFS. Kedalaman sebuah elemen
+// adalah 1 + kedalaman bahan terdalamnya; elemen baru dibuka setelah kedua bahannya selesai (settled).
+// f = g + h dengan h = jarak resep minimum ke target, yang admissible dan monoton terhadap kedalaman.
+// Tier target adalah kedalaman optimal, sehingga elemen dengan tier + h melebihi tier target tidak dibuka.
+// Di antara resep dengan kedalaman sama dipilih resep yang total ukuran pohon minimum bahannya terkecil.
+func FindPathAStar(targetElement string) ([]Recipe, int, error) {
+	tracef("A*: Mencari jalur terpendek ke: %s\n", targetElement)
+	dataset := getDatasetState()
+	recipesByResult := dataset.recipeMap
+	graph := GetAlchemyGraph()
+	if recipesByResult == nil || graph == nil {
+		return nil, 0, errors.New("alchemy graph not initialized")
+	}
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+
+	heuristics := getAStarHeuristics(dataset)
+	if _, makeable := heuristics.minSizes[targetElement]; !makeable {
+		return nil, 0, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	targetTier := heuristics.tiers[targetElement]
+	distance := distanceToTarget(targetElement, recipesByResult)
+
+	g := make(map[string]int)
+	cost := make(map[string]int)
+	recipeParent := make(map[string]Recipe)
+	settled := make(map[string]bool)
+	queue := &astarQueue{}
+	for _, base := range baseElements {
+		if _, useful := distance[base]; useful {
+			g[base] = 0
+			heap.Push(queue, astarEntry{element: base, g: 0, f: distance[base]})
+		}
+	}
+
+	nodesVisited := 0
+	for queue.Len() > 0 {
+		entry := heap.Pop(queue).(astarEntry)
+		if settled[entry.element] || entry.g != g[entry.element] || entry.cost != cost[entry.element] {
+			continue
+		}
+		settled[entry.element] = true
+		nodesVisited++
+
+		if entry.element == targetElement {
+			path := buildRecipePath(recipeParent, targetElement, g)
+			tracef("A*: Target '%s' ditemukan pada kedalaman %d setelah %d node.\n", targetElement, entry.g, nodesVisited)
+			return path, nodesVisited, nil
+		}
+
+		for _, recipe := range graph[entry.element] {
+			other := recipe.Ingredient1
+			if other == entry.element {
+				other = recipe.Ingredient2
+			}
+			result := recipe.Result
+			if !settled[other] || settled[result] || isBaseElement(result) {
+				continue
+			}
+			h, useful := distance[result]
+			if !useful || heuristics.tiers[result]+h > targetTier {
+				continue
+			}
+			candidateG := 1 + max(g[entry.element], g[other])
+			candidateCost := 1 + heuristics.minSizes[recipe.Ingredient1] + heuristics.minSizes[recipe.Ingredient2]
+			currentG, seen := g[result]
+			if seen && (candidateG > currentG || candidateG == currentG && candidateCost >= cost[result]) {
+				continue
+			}
+			g[result] = candidateG
+			cost[result] = candidateCost
+			recipeParent[result] = recipe
+			heap.Push(queue, astarEntry{element: result, g: candidateG, f: candidateG + h, cost: candidateCost})
+		}
+	}
+
+	tracef("A*: Target '%s' tidak ditemukan.\n", targetElement)
+	return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+}
@@ -0,0 +1,44 @@
+// src/backend/astar_test.go
+package main
+
+import "testing"
+
+func TestAStarMatchesTierDepthWithFewerNodes(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	tiers, _ := calculateElementTiers(flattenRecipeMap(GetRecipeMap()), baseElements)
+	var bfsNodes, astarNodes int
+	for _, element := range elements {
+		ResetCaches()
+		path, nodes, err := FindPathAStar(element)
+		if err != nil {
+			t.Errorf("astar: tidak ada jalur ke %s: %v", element, err)
+			continue
+		}
+		if err := ValidatePath(element, path); err != nil {
+			t.Errorf("astar: %v", err)
+			continue
+		}
+		if pathDepth(path) != tiers[element] {
+			t.Errorf("astar: kedalaman %s = %d, tier %d", element, pathDepth(path), tiers[element])
+		}
+
+		bfsPath, bfsVisited, err := FindPathBFS(element)
+		if err != nil {
+			continue
+		}
+		if pathDepth(path) > pathDepth(bfsPath) {
+			t.Errorf("astar: kedalaman %s = %d lebih dalam dari BFS (%d)", element, pathDepth(path), pathDepth(bfsPath))
+		}
+		bfsNodes += bfsVisited
+		astarNodes += nodes
+	}
+	if astarNodes >= bfsNodes {
+		t.Errorf("astar mengunjungi %d node, BFS %d", astarNodes, bfsNodes)
+	}
+}
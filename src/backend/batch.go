@@ -0,0 +1,175 @@
+// src/backend/batch.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	maxBatchItems      = 500
+	maxBatchBodyBytes  = 1 << 20
+	defaultBatchWorker = 4
+)
+
+type BatchSearchItem struct {
+	Target       string  `json:"target"`
+	Algo         string  `json:"algo,omitempty"`
+	Mode         string  `json:"mode,omitempty"`
+	Max          int     `json:"max,omitempty"`
+	MinDiversity float64 `json:"minDiversity,omitempty"`
+}
+
+type BatchSearchRequest struct {
+	Items   []BatchSearchItem `json:"items"`
+	Workers int               `json:"workers,omitempty"`
+}
+
+type BatchSearchStats struct {
+	Total               int   `json:"total"`
+	Succeeded           int   `json:"succeeded"`
+	PathsFound          int   `json:"pathsFound"`
+	Failed              int   `json:"failed"`
+	TotalNodesVisited   int   `json:"totalNodesVisited"`
+	TotalDurationMillis int64 `json:"totalDurationMillis"`
+	WallTimeMillis      int64 `json:"wallTimeMillis"`
+	Workers             int   `json:"workers"`
+}
+
+type BatchSearchResponse struct {
+	Results []MultiSearchResponse `json:"results"`
+	Stats   BatchSearchStats      `json:"stats"`
+}
+
+func batchSearchHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var request BatchSearchRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	if err := decoder.Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(request.Items) == 0 {
+		http.Error(w, "Field 'items' tidak boleh kosong", http.StatusBadRequest)
+		return
+	}
+	if len(request.Items) > maxBatchItems {
+		http.Error(w, fmt.Sprintf("Jumlah item batch maksimal %d", maxBatchItems), http.StatusBadRequest)
+		return
+	}
+
+	// Batas laju dibebankan per item di runBatchItem, jadi satu permintaan batch tidak bisa menjalankan
+	// ratusan pencarian dengan satu token.
+	response := runBatchSearch(r.Context(), clientIP(r), request)
+	writeJSON(w, response)
+}
+
+func runBatchSearch(ctx context.Context, client string, request BatchSearchRequest) BatchSearchResponse {
+	workers := request.Workers
+	if workers <= 0 {
+		workers = defaultBatchWorker
+	}
+	workers = min(workers, runtime.NumCPU(), len(request.Items))
+
+	log.Printf("Memulai batch pencarian: %d item, %d worker\n", len(request.Items), workers)
+	startTime := time.Now()
+
+	results := make([]MultiSearchResponse, len(request.Items))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				results[idx] = runBatchItem(ctx, client, request.Items[idx])
+			}
+		}()
+	}
+	for idx := range request.Items {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+
+	stats := BatchSearchStats{
+		Total:          len(results),
+		Workers:        workers,
+		WallTimeMillis: time.Since(startTime).Milliseconds(),
+	}
+	for _, result := range results {
+		if result.Error != "" {
+			stats.Failed++
+		} else {
+			stats.Succeeded++
+		}
+		if result.PathFound {
+			stats.PathsFound++
+		}
+		stats.TotalNodesVisited += result.NodesVisited
+		stats.TotalDurationMillis += result.DurationMillis
+	}
+	log.Printf("Batch pencarian selesai: %d berhasil, %d gagal, waktu=%dms\n", stats.Succeeded, stats.Failed, stats.WallTimeMillis)
+
+	return BatchSearchResponse{Results: results, Stats: stats}
+}
+
+// Kesalahan per item dicatat pada hasil item tersebut tanpa menggagalkan seluruh batch.
+func runBatchItem(ctx context.Context, client string, item BatchSearchItem) MultiSearchResponse {
+	targetElement := resolveElementName(item.Target)
+	algo, mode := normalizeSearchParams(item.Algo, item.Mode)
+	failed := MultiSearchResponse{SearchTarget: targetElement, Algorithm: algo, Mode: mode}
+
+	if err := validateSearchParams(targetElement, algo, mode); err != nil {
+		failed.Error = err.Error()
+		return failed
+	}
+
+	maxRecipes := 1
+	if mode == "multiple" {
+		if item.Max <= 0 {
+			failed.Error = "Field 'max' harus berupa angka positif untuk mode 'multiple'"
+			return failed
+		}
+		maxRecipes = limitMaxRecipes(targetElement, item.Max)
+		failed.MaxRecipes = maxRecipes
+	}
+	if item.MinDiversity < 0 || item.MinDiversity > 1 {
+		failed.Error = "Field 'minDiversity' harus berupa angka antara 0 dan 1"
+		return failed
+	}
+
+	queueCtx, cancel := context.WithTimeout(ctx, searchLimits.QueueTimeout)
+	defer cancel()
+	if err := searchRateLimiter.wait(queueCtx, client); err != nil {
+		failed.Error = "Terlalu banyak permintaan, item tidak sempat dijalankan"
+		return failed
+	}
+	granted, err := searchSemaphore.acquire(queueCtx, estimateSearchCost(algo, mode, diversityFetchCount(maxRecipes, item.MinDiversity), SearchOptions{}))
+	if err != nil {
+		failed.Error = "Server sedang sibuk, item tidak sempat dijalankan"
+		return failed
+	}
+	defer searchSemaphore.release(granted)
+
+	return runSearch(targetElement, algo, mode, maxRecipes, item.MinDiversity)
+}
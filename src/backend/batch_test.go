@@ -0,0 +1,35 @@
+// src/backend/batch_test.go
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// Setiap item batch memakai satu token, jadi item di atas burst gagal ketika token tidak terisi ulang
+// sebelum antrean habis.
+func TestBatchChargesRateLimitPerItem(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+	previousLimits, previousLimiter := searchLimits, searchRateLimiter
+	t.Cleanup(func() { searchLimits, searchRateLimiter = previousLimits, previousLimiter })
+	searchLimits.QueueTimeout = 50 * time.Millisecond
+	searchRateLimiter = newClientRateLimiter(0.01, 2)
+
+	items := []BatchSearchItem{{Target: "Mud"}, {Target: "Brick"}, {Target: "Steam"}}
+	response := runBatchSearch(context.Background(), "192.0.2.1", BatchSearchRequest{Items: items, Workers: 1})
+	if response.Stats.Succeeded != 2 || response.Stats.Failed != 1 {
+		t.Fatalf("seharusnya 2 item berhasil dan 1 ditolak: %+v", response.Stats)
+	}
+}
+
+func TestBatchBoundsMaxByTreeCount(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	response := runBatchSearch(context.Background(), "192.0.2.2", BatchSearchRequest{Items: []BatchSearchItem{{Target: "Mud", Mode: "multiple", Max: 50}}})
+	if got := response.Results[0].MaxRecipes; got != 1 {
+		t.Errorf("max untuk Mud seharusnya dipotong menjadi jumlah pohonnya (1), didapat %d", got)
+	}
+}
@@ -1,367 +1,300 @@
 package main
 
 import (
-	"container/list"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
-	"sync"
-	"sync/atomic"
 )
 
-func reconstructSingleSegmentPath(parentMap map[string]Recipe, startNode string, stopCondition func(string) bool) []Recipe {
-	pathList := list.New()
-	processed := make(map[string]bool)
-	curr := startNode
-	for curr != "" && !stopCondition(curr) {
-		recipe, exists := parentMap[curr]
-		if !exists {
-			break
-		}
-		recipeKey := getUniqueRecipeKey(recipe)
-		if processed[recipeKey] {
-			break
-		}
-		pathList.PushFront(recipe)
-		processed[recipeKey] = true
-		_, p1Exists := parentMap[recipe.Ingredient1]
-		_, p2Exists := parentMap[recipe.Ingredient2]
-		chosenParent := ""
-		if p1Exists && p2Exists {
-			if recipe.Ingredient1 < recipe.Ingredient2 {
-				chosenParent = recipe.Ingredient1
-			} else {
-				chosenParent = recipe.Ingredient2
-			}
-		} else if p1Exists {
-			chosenParent = recipe.Ingredient1
-		} else if p2Exists {
-			chosenParent = recipe.Ingredient2
-		} else {
-			if isBaseElement(recipe.Ingredient1) && stopCondition(recipe.Ingredient1) {
-				chosenParent = recipe.Ingredient1
-			} else if isBaseElement(recipe.Ingredient2) && stopCondition(recipe.Ingredient2) {
-				chosenParent = recipe.Ingredient2
-			} else if isBaseElement(recipe.Ingredient1) && !stopCondition(recipe.Ingredient1) {
-				chosenParent = recipe.Ingredient1
-			} else if isBaseElement(recipe.Ingredient2) && !stopCondition(recipe.Ingredient2) {
-				chosenParent = recipe.Ingredient2
-			} else {
-				chosenParent = ""
-			}
-		}
-		curr = chosenParent
-	}
-	finalPath := make([]Recipe, 0, pathList.Len())
-	for e := pathList.Front(); e != nil; e = e.Next() {
-		finalPath = append(finalPath, e.Value.(Recipe))
-	}
-	return finalPath
+func init() {
+	RegisterSearcher(funcSearcher{
+		name:         "bds",
+		description:  "Bidirectional Search: closure maju dari elemen dasar bertemu ekspansi AND-OR mundur dari target; kedalaman minimum",
+		capabilities: SearchCapabilities{Modes: []string{"shortest", "multiple"}, Deterministic: true},
+		shortest:     FindPathBDS,
+		multiple:     multiplePathsFunc(FindMultiplePathsBDS),
+		// satu pencarian BDS lalu enumerasi k-best untuk jalur sisanya, jadi biayanya mengikuti kbest
+		cost: func(maxRecipes int) int64 { return int64(1 + maxRecipes/10) },
+	})
 }
 
-func buildSortedPathFromRecipes(recipes map[string]Recipe, targetElement string) []Recipe {
-	fmt.Println("  Mengurutkan resep gabungan berdasarkan dependensi...")
-	if len(recipes) == 0 {
-		return []Recipe{}
-	}
+// bdsForward adalah closure maju: elemen yang bisa dibuat dengan kedalaman paling banyak level,
+// dibangun per lapisan dari elemen dasar (kedalaman = 1 + kedalaman bahan terdalam). depth bernilai -1
+// untuk elemen yang belum masuk closure.
+type bdsForward struct {
+	g        *CompactGraph
+	depth    []int32
+	parent   []int32
+	frontier []int32
+	level    int
+}
 
-	elementsInvolved := make(map[string]bool)
-	for _, r := range recipes {
-		elementsInvolved[r.Ingredient1] = true
-		elementsInvolved[r.Ingredient2] = true
-		elementsInvolved[r.Result] = true
-	}
+func newBDSForward(g *CompactGraph) *bdsForward {
+	return newBDSForwardFrom(g, g.base)
+}
 
-	available := make(map[string]bool)
-	for _, base := range baseElements {
-		if elementsInvolved[base] {
-			available[base] = true
+// newBDSForwardFrom memulai closure maju dari elemen yang ditandai known (misalnya inventaris pemain)
+// sebagai lapisan 0, bukan hanya dari elemen dasar.
+func newBDSForwardFrom(g *CompactGraph, known []bool) *bdsForward {
+	forward := &bdsForward{g: g, depth: make([]int32, g.NumElements()), parent: make([]int32, g.NumElements())}
+	for id := range forward.depth {
+		forward.depth[id] = -1
+		forward.parent[id] = -1
+		if known[id] {
+			forward.depth[id] = 0
+			forward.frontier = append(forward.frontier, int32(id))
 		}
 	}
+	return forward
+}
 
-	remainingRecipes := make(map[string]Recipe)
-	for k, v := range recipes {
-		remainingRecipes[k] = v
-	}
-
-	sortedPath := make([]Recipe, 0, len(recipes))
-	iterations := 0
-	maxIterations := len(recipes)*2 + 10
-
-	for !available[targetElement] && iterations < maxIterations {
-		addedRecipeInIteration := false
-		candidates := make([]Recipe, 0)
-		candidateKeys := make([]string, 0)
+// closed berarti sub-goal element dengan sisa kedalaman budget sudah dipenuhi closure maju.
+func (f *bdsForward) closed(element int32, budget int) bool {
+	return f.depth[element] >= 0 && int(f.depth[element]) <= budget
+}
 
-		for key, recipe := range remainingRecipes {
-			if available[recipe.Ingredient1] && available[recipe.Ingredient2] {
-				candidates = append(candidates, recipe)
-				candidateKeys = append(candidateKeys, key)
+// grow menambah satu lapisan: setiap lapisan baru memakai paling sedikit satu bahan dari lapisan terakhir.
+// Jika ada beberapa resep, dipilih resep dengan getRecipeID terkecil.
+func (f *bdsForward) grow() int {
+	f.level++
+	var next []int32
+	for _, element := range f.frontier {
+		for _, rid := range f.g.Uses(element) {
+			result := f.g.recipes[rid].result
+			if !f.closed(f.g.Other(rid, element), f.level-1) || f.g.IsBase(result) {
+				continue
 			}
+			if f.depth[result] >= 0 && int(f.depth[result]) < f.level {
+				continue
+			}
+			if f.depth[result] < 0 {
+				f.depth[result] = int32(f.level)
+				next = append(next, result)
+			} else if f.g.rank[rid] >= f.g.rank[f.parent[result]] {
+				continue
+			}
+			f.parent[result] = rid
 		}
-
-		if len(candidates) == 0 {
-			fmt.Printf("  ERROR (Sort): Tidak ada kandidat resep yang bisa dibuat, target '%s' belum tersedia. Elemen tersedia: %v\n", targetElement, available)
-			return sortedPath
-		}
-
-		sort.SliceStable(candidates, func(i, j int) bool {
-			return candidates[i].Result < candidates[j].Result
-		})
-
-		for i, recipe := range candidates {
-			key := candidateKeys[i]
-			sortedPath = append(sortedPath, recipe)
-			available[recipe.Result] = true
-			delete(remainingRecipes, key)
-			addedRecipeInIteration = true
-		}
-
-		if !addedRecipeInIteration && !available[targetElement] {
-			fmt.Printf("  ERROR (Sort): Tidak ada resep baru ditambahkan di iterasi %d, target '%s' belum tersedia.\n", iterations+1, targetElement)
-			return sortedPath
-		}
-		iterations++
 	}
-
-	if iterations >= maxIterations {
-		fmt.Printf("  ERROR (Sort): Melebihi batas iterasi maksimum (%d), target '%s' mungkin tidak tercapai atau ada loop dependensi.\n", maxIterations, targetElement)
-	} else if !available[targetElement] {
-		fmt.Printf("  PERINGATAN (Sort): Loop selesai, tapi target '%s' tidak tersedia di akhir.\n", targetElement)
-	} else {
-		fmt.Printf("  Pengurutan resep selesai. Total langkah terurut: %d\n", len(sortedPath))
-	}
-
-	return sortedPath
+	slices.Sort(next)
+	f.frontier = next
+	return len(next)
 }
 
-func FindPathBDS(targetElement string) ([]Recipe, int, error) {
-	fmt.Printf("Hybrid BDS+BFS: Mencari jalur ke: %s\n", targetElement)
-	recipeMap := GetRecipeMap()
-	alchemyGraph := GetAlchemyGraph()
-	if recipeMap == nil || alchemyGraph == nil {
-		return nil, 0, errors.New("data resep/graf belum diinisialisasi")
-	}
-	if isBaseElement(targetElement) {
-		return []Recipe{}, 0, nil
-	}
-
-	nodesVisitedCount := 0
-	queueForward := list.New()
-	visitedForward := make(map[string]int)
-	parentForward := make(map[string]Recipe)
-	queueBackward := list.New()
-	visitedBackward := make(map[string]int)
-	parentBackward := make(map[string]Recipe)
-
-	// Inisialisasi
-	for _, base := range baseElements {
-		if visitedForward[base] == 0 {
-			queueForward.PushBack(base)
-			visitedForward[base] = 1
-		}
-	}
-	if visitedBackward[targetElement] == 0 {
-		queueBackward.PushBack(targetElement)
-		visitedBackward[targetElement] = 1
-	}
-
-	currentLevelForward := 1
-	currentLevelBackward := 1
-	var meetingNode string = ""
-
-	for queueForward.Len() > 0 && queueBackward.Len() > 0 && meetingNode == "" {
-
-		lenF := queueForward.Len()
-		for i := 0; i < lenF && meetingNode == ""; i++ {
-			if queueForward.Len() == 0 {
-				break
-			}
-			currF := queueForward.Remove(queueForward.Front()).(string)
-			nodesVisitedCount++
+// bdsBackward adalah ekspansi AND-OR mundur dari target. Lapisan ke-i berisi sub-goal dengan sisa
+// kedalaman bound-i; sub-goal yang belum tertutup closure maju dibuka lewat semua resepnya, dan
+// kedua bahan resep menjadi sub-goal di lapisan berikutnya (keduanya harus terpenuhi). solved dan chosen
+// sejajar dengan layers (indeks posisi di lapisan), jadi ukurannya mengikuti lapisan, bukan jumlah elemen.
+type bdsBackward struct {
+	bound  int
+	layers [][]int32
+	solved [][]bool
+	chosen [][]int32
+}
 
-			if visitedBackward[currF] > 0 {
-				meetingNode = currF
+// expandBackward membuka depth lapisan mundur untuk batas kedalaman bound. Sub-goal di lapisan terakhir
+// adalah daun terbuka yang hanya bisa ditutup oleh closure maju. Mengembalikan jumlah sub-goal yang dibuka.
+func expandBackward(target int32, bound, depth int, forward *bdsForward) (*bdsBackward, int) {
+	g := forward.g
+	backward := &bdsBackward{bound: bound, layers: make([][]int32, 1, depth+1)}
+	backward.layers[0] = []int32{target}
+	opened := 0
+	for i := 0; i < depth; i++ {
+		budget := bound - i
+		var next []int32
+		for _, element := range backward.layers[i] {
+			if forward.closed(element, budget) {
+				continue
 			}
-
-			recipesUsingCurrF := alchemyGraph[currF]
-			for _, recipe := range recipesUsingCurrF {
-				otherIng := ""
-				if recipe.Ingredient1 == currF {
-					otherIng = recipe.Ingredient2
-				} else if recipe.Ingredient2 == currF {
-					otherIng = recipe.Ingredient1
-				} else {
-					continue
-				}
-
-				if visitedForward[otherIng] > 0 && visitedForward[otherIng] <= currentLevelForward {
-					result := recipe.Result
-					if visitedForward[result] == 0 {
-						visitedForward[result] = currentLevelForward + 1
-						parentForward[result] = recipe
-						queueForward.PushBack(result)
-
-						if visitedBackward[result] > 0 && meetingNode == "" {
-							meetingNode = result
-						}
+			opened++
+			for _, rid := range g.ProducersByRank(element) {
+				recipe := g.recipes[rid]
+				for _, ingredient := range []int32{recipe.ingredient1, recipe.ingredient2} {
+					if !g.IsBase(ingredient) {
+						next = append(next, ingredient)
 					}
 				}
 			}
 		}
-		if meetingNode != "" {
-			break
-		}
-		currentLevelForward++
-
-		lenB := queueBackward.Len()
-		for i := 0; i < lenB && meetingNode == ""; i++ {
-			if queueBackward.Len() == 0 {
-				break
-			}
-			currB := queueBackward.Remove(queueBackward.Front()).(string)
-			nodesVisitedCount++
+		// Duplikat dibuang dengan sort + compact supaya tidak perlu array penanda seukuran jumlah elemen.
+		slices.Sort(next)
+		backward.layers = append(backward.layers, slices.Compact(next))
+	}
 
-			if visitedForward[currB] > 0 {
-				meetingNode = currB
+	// Evaluasi dari lapisan terdalam: sub-goal terpenuhi jika tertutup closure maju, atau ada resep yang
+	// kedua bahannya terpenuhi di lapisan berikutnya.
+	backward.solved = make([][]bool, len(backward.layers))
+	backward.chosen = make([][]int32, len(backward.layers))
+	for i := len(backward.layers) - 1; i >= 0; i-- {
+		budget := bound - i
+		layer := backward.layers[i]
+		backward.solved[i] = make([]bool, len(layer))
+		backward.chosen[i] = make([]int32, len(layer))
+		for position, element := range layer {
+			if forward.closed(element, budget) {
+				backward.solved[i][position] = true
+				continue
 			}
-
-			recipesMakingCurrB := recipeMap[currB]
-			if _, exists := parentBackward[currB]; !exists && len(recipesMakingCurrB) > 0 {
-				parentBackward[currB] = recipesMakingCurrB[0]
+			if i == len(backward.layers)-1 {
+				continue
 			}
-
-			for _, recipe := range recipesMakingCurrB {
-				ingredients := []string{recipe.Ingredient1, recipe.Ingredient2}
-				for _, ing := range ingredients {
-					if visitedBackward[ing] == 0 {
-						visitedBackward[ing] = currentLevelBackward + 1
-						queueBackward.PushBack(ing)
-
-						if visitedForward[ing] > 0 && meetingNode == "" {
-							meetingNode = ing
-						}
-					}
+			for _, rid := range g.ProducersByRank(element) {
+				recipe := g.recipes[rid]
+				if backward.met(g, recipe.ingredient1, i+1) && backward.met(g, recipe.ingredient2, i+1) {
+					backward.solved[i][position] = true
+					backward.chosen[i][position] = rid
+					break
 				}
 			}
 		}
-		if meetingNode != "" {
-			break
-		}
-		currentLevelBackward++
-
-		if queueForward.Len() == 0 || queueBackward.Len() == 0 {
-			break
-		}
 	}
+	return backward, opened
+}
 
-	if meetingNode == "" {
-		fmt.Printf("Hybrid BDS+BFS: Tidak ada pertemuan ditemukan untuk '%s'.\n", targetElement)
-		return nil, nodesVisitedCount, fmt.Errorf("jalur (BDS meeting) ke '%s' tidak ditemukan", targetElement)
+// position mengembalikan indeks element pada lapisan yang terurut, atau -1 jika tidak ada.
+func (b *bdsBackward) position(layer int, element int32) int {
+	elements := b.layers[layer]
+	position := sort.Search(len(elements), func(k int) bool { return elements[k] >= element })
+	if position < len(elements) && elements[position] == element {
+		return position
 	}
+	return -1
+}
 
-	fmt.Printf("Hybrid BDS+BFS: Pertemuan di '%s'. Memulai rekonstruksi dan pencarian BFS tambahan...\n", meetingNode)
-
-	finalRecipe, finalRecipeExists := parentBackward[targetElement]
-	if !finalRecipeExists {
-		recipesForTarget := recipeMap[targetElement]
-		if len(recipesForTarget) > 0 {
-			finalRecipe = recipesForTarget[0]
-			finalRecipeExists = true
-		} else {
-			fmt.Printf("  ERROR: Tidak dapat menemukan resep final untuk '%s'.\n", targetElement)
-			return nil, nodesVisitedCount, fmt.Errorf("resep final untuk '%s' tidak ditemukan", targetElement)
-		}
+func (b *bdsBackward) met(g *CompactGraph, element int32, layer int) bool {
+	if g.IsBase(element) {
+		return true
 	}
+	position := b.position(layer, element)
+	return position >= 0 && b.solved[layer][position]
+}
 
-	ing1 := finalRecipe.Ingredient1
-	ing2 := finalRecipe.Ingredient2
-
-	combinedRecipes := make(map[string]Recipe)
-
-	if meetingNode == ing1 || meetingNode == ing2 {
-		var ingredientToSearchBFS string
-		var pathForMeetingNodeSegment []Recipe
-
-		if meetingNode == ing1 {
-			ingredientToSearchBFS = ing2
-		} else {
-			ingredientToSearchBFS = ing1
-		}
-
-		fmt.Printf("  Merekonstruksi jalur FWD untuk meeting node '%s'...\n", meetingNode)
-		stopAtBase := func(node string) bool { return isBaseElement(node) }
-		pathForMeetingNodeSegment = reconstructSingleSegmentPath(parentForward, meetingNode, stopAtBase)
-		fmt.Printf("  Jalur FWD untuk '%s' ditemukan (panjang: %d)\n", meetingNode, len(pathForMeetingNodeSegment))
-
-		fmt.Printf("  Mencari jalur BFS untuk bahan '%s'\n", ingredientToSearchBFS)
-		pathOtherIngredient, bfsNodes, errBFS := FindPathBFS(ingredientToSearchBFS)
-		if errBFS != nil {
-			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ingredientToSearchBFS, errBFS)
-			return nil, nodesVisitedCount + bfsNodes, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ingredientToSearchBFS, errBFS)
+// openLeaves mengembalikan daun terbuka di lapisan terakhir yang belum bertemu closure maju.
+func (b *bdsBackward) openLeaves(forward *bdsForward) int {
+	last := len(b.layers) - 1
+	open := 0
+	for _, element := range b.layers[last] {
+		if !forward.closed(element, b.bound-last) {
+			open++
 		}
-		nodesVisitedCount += bfsNodes
-		fmt.Printf("  Jalur BFS untuk '%s' ditemukan (panjang: %d)\n", ingredientToSearchBFS, len(pathOtherIngredient))
+	}
+	return open
+}
 
-		for _, r := range pathForMeetingNodeSegment {
-			combinedRecipes[getUniqueRecipeKey(r)] = r
+// plan menggabungkan pilihan resep mundur dengan resep closure maju menjadi satu resep per elemen.
+// Elemen yang diminta pada beberapa lapisan memakai resep dari lapisan dengan sisa kedalaman terkecil;
+// resep itu juga memenuhi lapisan lain karena sisa kedalamannya lebih longgar.
+func (b *bdsBackward) plan(target int32, forward *bdsForward) []Recipe {
+	g := forward.g
+	recipeParent := make(map[string]Recipe)
+	budgets := make(map[string]int)
+	var visit func(element int32, layer int)
+	visit = func(element int32, layer int) {
+		budget := b.bound - layer
+		if g.IsBase(element) {
+			return
 		}
-		for _, r := range pathOtherIngredient {
-			combinedRecipes[getUniqueRecipeKey(r)] = r
+		name := g.Name(element)
+		if current, exists := budgets[name]; exists && current <= budget {
+			return
 		}
-
-	} else {
-		fmt.Printf("  PERINGATAN: Meeting node '%s' bukan bahan langsung. Mencari BFS untuk KEDUA bahan '%s' dan '%s'.\n", meetingNode, ing1, ing2)
-
-		fmt.Printf("  Mencari jalur BFS untuk bahan 1: '%s'\n", ing1)
-		pathIng1, bfsNodes1, err1 := FindPathBFS(ing1)
-		if err1 != nil {
-			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ing1, err1)
-			return nil, nodesVisitedCount + bfsNodes1, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ing1, err1)
-		}
-		nodesVisitedCount += bfsNodes1
-		fmt.Printf("  Jalur BFS untuk '%s' ditemukan (panjang: %d)\n", ing1, len(pathIng1))
-		for _, r := range pathIng1 {
-			combinedRecipes[getUniqueRecipeKey(r)] = r
+		budgets[name] = budget
+		var rid int32
+		var nextLayer int
+		if forward.closed(element, budget) {
+			rid = forward.parent[element]
+			nextLayer = b.bound - int(forward.depth[element]) + 1
+		} else {
+			rid = b.chosen[layer][b.position(layer, element)]
+			nextLayer = layer + 1
 		}
+		recipeParent[name] = g.Recipe(rid)
+		visit(g.recipes[rid].ingredient1, nextLayer)
+		visit(g.recipes[rid].ingredient2, nextLayer)
+	}
+	visit(target, 0)
+	return buildRecipePath(recipeParent, g.Name(target), planDepths(recipeParent, g.Name(target)))
+}
 
-		fmt.Printf("  Mencari jalur BFS untuk bahan 2: '%s'\n", ing2)
-		pathIng2, bfsNodes2, err2 := FindPathBFS(ing2)
-		if err2 != nil {
-			fmt.Printf("  ERROR: Gagal mencari jalur BFS untuk '%s': %v\n", ing2, err2)
-			return nil, nodesVisitedCount + bfsNodes2, fmt.Errorf("gagal mencari jalur BFS untuk bahan '%s': %v", ing2, err2)
-		}
-		nodesVisitedCount += bfsNodes2
-		fmt.Printf("  Jalur BFS untuk '%s' ditemukan (panjang: %d)\n", ing2, len(pathIng2))
-		for _, r := range pathIng2 {
-			combinedRecipes[getUniqueRecipeKey(r)] = r
+// planDepths menghitung kedalaman setiap elemen pada rencana (elemen dasar 0, selain itu 1 + kedalaman bahan
+// terdalam) untuk urutan langkah buildRecipePath. Sisa kedalaman dari plan tidak bisa dipakai karena nilainya
+// justru mengecil untuk elemen yang lebih dalam.
+func planDepths(recipeParent map[string]Recipe, target string) map[string]int {
+	depths := make(map[string]int, len(recipeParent))
+	var depthOf func(element string) int
+	depthOf = func(element string) int {
+		if depth, exists := depths[element]; exists {
+			return depth
 		}
-
-		fmt.Printf("  Merekonstruksi jalur FWD untuk meeting node '%s' (kasus 2)...\n", meetingNode)
-		stopAtBase := func(node string) bool { return isBaseElement(node) }
-		pathMeetingToBase := reconstructSingleSegmentPath(parentForward, meetingNode, stopAtBase)
-		fmt.Printf("  Jalur FWD untuk '%s' ditemukan (panjang: %d)\n", meetingNode, len(pathMeetingToBase))
-		for _, r := range pathMeetingToBase {
-			combinedRecipes[getUniqueRecipeKey(r)] = r
+		recipe, exists := recipeParent[element]
+		if !exists {
+			return 0
 		}
+		depths[element] = 0
+		depth := 1 + max(depthOf(recipe.Ingredient1), depthOf(recipe.Ingredient2))
+		depths[element] = depth
+		return depth
 	}
+	depthOf(target)
+	return depths
+}
 
-	combinedRecipes[getUniqueRecipeKey(finalRecipe)] = finalRecipe
+// FindPathBDS mencari rencana dengan kedalaman minimum dari dua arah. Closure maju tumbuh per lapisan
+// kedalaman dari elemen dasar, sedangkan sisi mundur mengekspansi target sebagai graf AND-OR: setiap resep
+// membuka dua sub-goal yang keduanya harus dipenuhi. Batas kedalaman total (lapisan maju + lapisan mundur)
+// naik satu per langkah dengan memperbesar sisi yang frontier-nya lebih kecil, dan pencarian selesai ketika
+// semua daun terbuka pada salah satu pilihan resep bertemu closure maju. Karena batas naik satu per satu,
+// batas pertama yang berhasil adalah kedalaman minimum (tier target).
+func FindPathBDS(targetElement string) ([]Recipe, int, error) {
+	tracef("BDS: Mencari jalur ke: %s\n", targetElement)
+	g := GetCompactGraph()
+	if g == nil {
+		return nil, 0, errors.New("data resep/graf belum diinisialisasi")
+	}
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+	target, exists := g.ID(targetElement)
+	if !exists {
+		return nil, 0, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
 
-	finalPathSorted := buildSortedPathFromRecipes(combinedRecipes, targetElement)
+	forward := newBDSForward(g)
+	nodesVisited := len(forward.frontier)
+	backwardDepth := 0
+	backwardFrontier := 1
+
+	for {
+		// Sisi dengan frontier lebih kecil yang diperbesar. Sisi maju tetap diperbesar jika sisi mundur sudah
+		// lebih dalam atau tidak punya daun terbuka, supaya closure maju pasti jenuh untuk target yang mustahil.
+		if len(forward.frontier) <= backwardFrontier || backwardFrontier == 0 || backwardDepth > forward.level {
+			added := forward.grow()
+			nodesVisited += added
+			if added == 0 {
+				// Closure maju sudah berisi semua elemen yang bisa dibuat; jika target ada di dalamnya,
+				// pencarian sudah berhasil pada langkah sebelumnya.
+				tracef("BDS: Closure maju jenuh di lapisan %d tanpa mencapai '%s'.\n", forward.level-1, targetElement)
+				return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+			}
+		} else {
+			backwardDepth++
+		}
 
-	if len(finalPathSorted) == 0 && !isBaseElement(targetElement) {
-		fmt.Printf("  PERINGATAN AKHIR: Jalur terurut kosong untuk target non-dasar '%s'.\n", targetElement)
-	} else if len(finalPathSorted) > 0 && finalPathSorted[len(finalPathSorted)-1].Result != targetElement {
-		fmt.Printf("  PERINGATAN AKHIR: Jalur terurut TIDAK menghasilkan target '%s'. Resep terakhir: %v\n", targetElement, finalPathSorted[len(finalPathSorted)-1].Result)
+		bound := forward.level + backwardDepth
+		backward, opened := expandBackward(target, bound, backwardDepth, forward)
+		nodesVisited += opened
+		if backward.solved[0][0] {
+			path := backward.plan(target, forward)
+			tracef("BDS: Semua sub-goal bertemu closure maju pada kedalaman %d (maju %d, mundur %d), %d node.\n", bound, forward.level, backwardDepth, nodesVisited)
+			return path, nodesVisited, nil
+		}
+		backwardFrontier = backward.openLeaves(forward)
 	}
-
-	fmt.Printf("Hybrid BDS+BFS: Penggabungan dan pengurutan selesai. Total resep unik terurut: %d\n", len(finalPathSorted))
-	return finalPathSorted, nodesVisitedCount, nil
 }
 
 func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
-	fmt.Printf("BDS Multiple (Hybrid): Mencari %d jalur ke: %s (Multithreaded)\n", maxRecipes, targetElement)
+	tracef("BDS Multiple: Mencari %d jalur ke: %s\n", maxRecipes, targetElement)
 
 	if maxRecipes <= 0 {
 		return nil, 0, errors.New("jumlah resep minimal harus 1")
@@ -370,83 +303,39 @@ func FindMultiplePathsBDS(targetElement string, maxRecipes int) ([][]Recipe, int
 		return [][]Recipe{{}}, 0, nil
 	}
 
+	// FindPathBDS deterministik, jadi variasi jalur berikutnya diambil dari enumerasi k-best.
 	var allFoundPaths [][]Recipe
-	addedPathIdentifiers := make(map[string]bool)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-	nodesVisitedTotal := atomic.Int32{}
-	foundCount := atomic.Int32{}
-	quitChan := make(chan struct{})
-	var quitOnce sync.Once
-	closeQuitChan := func() {
-		quitOnce.Do(func() { close(quitChan) })
-	}
-	defer closeQuitChan()
-
-	numGoroutines := maxRecipes
-	if numGoroutines < 1 {
-		numGoroutines = 1
-	}
-	maxGo := 10
-	if numGoroutines > maxGo {
-		numGoroutines = maxGo
+	addedPlans := make(map[string]bool)
+	nodesVisitedTotal := 0
+
+	path, nodesVisited, err := FindPathBDS(targetElement)
+	nodesVisitedTotal += nodesVisited
+	if err == nil && len(path) > 0 {
+		allFoundPaths = append(allFoundPaths, path)
+		addedPlans[planIdentifier(path)] = true
 	}
 
-	fmt.Printf("BDS Multiple (Hybrid): Meluncurkan %d goroutine...\n", numGoroutines)
-
-	for i := 0; i < numGoroutines; i++ {
-		if foundCount.Load() >= int32(maxRecipes) {
-			break
-		}
-		wg.Add(1)
-		go func(goroutineIndex int) {
-			defer wg.Done()
-
-			path, nodesVisited, err := FindPathBDS(targetElement)
-			nodesVisitedTotal.Add(int32(nodesVisited))
-			mu.Lock()
-			defer mu.Unlock()
-			select {
-			case <-quitChan:
-				return
-			default:
-			}
-			if err == nil && path != nil {
-				if len(path) > 0 {
-					pathID := generatePathIdentifier(path)
-					if !addedPathIdentifiers[pathID] {
-						if currentFound := foundCount.Load(); currentFound < int32(maxRecipes) {
-							pathToAppend := make([]Recipe, len(path))
-							copy(pathToAppend, path)
-							allFoundPaths = append(allFoundPaths, pathToAppend)
-							addedPathIdentifiers[pathID] = true
-							newCount := foundCount.Add(1)
-							fmt.Printf("Goroutine Hybrid-%d: Jalur UNIK ditemukan (Panjang: %d). Total Ditemukan: %d/%d\n", goroutineIndex, len(pathToAppend), newCount, maxRecipes)
-							if newCount >= int32(maxRecipes) {
-								closeQuitChan()
-							}
-						}
-					}
+	if len(allFoundPaths) < maxRecipes {
+		kBest, kNodes, kErr := FindKBestPaths(targetElement, maxRecipes)
+		nodesVisitedTotal += kNodes
+		if kErr == nil {
+			for _, candidate := range kBest.Paths {
+				if len(allFoundPaths) >= maxRecipes {
+					break
+				}
+				planID := planIdentifier(candidate)
+				if !addedPlans[planID] {
+					addedPlans[planID] = true
+					allFoundPaths = append(allFoundPaths, candidate)
 				}
 			}
-		}(i)
+		}
 	}
 
-	wg.Wait()
-	mu.Lock()
-	finalPathsToReturn := make([][]Recipe, len(allFoundPaths))
-	copy(finalPathsToReturn, allFoundPaths)
-	currentFoundCount := len(finalPathsToReturn)
-	mu.Unlock()
-
-	if currentFoundCount == 0 && !isBaseElement(targetElement) {
-		return nil, int(nodesVisitedTotal.Load()), fmt.Errorf("tidak ada jalur Hybrid BDS+BFS (multiple) yang valid ditemukan untuk '%s'", targetElement)
+	if len(allFoundPaths) == 0 {
+		return nil, nodesVisitedTotal, fmt.Errorf("tidak ada jalur BDS (multiple) yang valid ditemukan untuk '%s'", targetElement)
 	}
 
-	sort.SliceStable(finalPathsToReturn, func(i, j int) bool {
-		return len(finalPathsToReturn[i]) < len(finalPathsToReturn[j])
-	})
-
-	fmt.Printf("BDS Multiple (Hybrid): Selesai. Total jalur unik ditemukan: %d (diminta: %d). Total nodes visited (approx): %d\n", currentFoundCount, maxRecipes, nodesVisitedTotal.Load())
-	return finalPathsToReturn, int(nodesVisitedTotal.Load()), nil
+	tracef("BDS Multiple: Selesai. Total jalur unik ditemukan: %d (diminta: %d). Total nodes visited: %d\n", len(allFoundPaths), maxRecipes, nodesVisitedTotal)
+	return allFoundPaths, nodesVisitedTotal, nil
 }
@@ -0,0 +1,259 @@
+// src/backend/bds_legacy_test.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// legacyBDSForward adalah closure maju: elemen yang bisa dibuat dengan kedalaman paling banyak level,
+// dibangun per lapisan dari elemen dasar (kedalaman = 1 + kedalaman bahan terdalam).
+type legacyBDSForward struct {
+	depth    map[string]int
+	parent   map[string]Recipe
+	frontier []string
+	level    int
+}
+
+func newLegacyBDSForward() *legacyBDSForward {
+	forward := &legacyBDSForward{depth: make(map[string]int), parent: make(map[string]Recipe)}
+	for _, base := range baseElements {
+		if _, exists := forward.depth[base]; !exists {
+			forward.depth[base] = 0
+			forward.frontier = append(forward.frontier, base)
+		}
+	}
+	sort.Strings(forward.frontier)
+	return forward
+}
+
+// closed berarti sub-goal element dengan sisa kedalaman budget sudah dipenuhi closure maju.
+func (f *legacyBDSForward) closed(element string, budget int) bool {
+	depth, exists := f.depth[element]
+	return exists && depth <= budget
+}
+
+// grow menambah satu lapisan: setiap lapisan baru memakai paling sedikit satu bahan dari lapisan terakhir.
+func (f *legacyBDSForward) grow(graph map[string][]Recipe) int {
+	f.level++
+	next := make(map[string]Recipe)
+	for _, element := range f.frontier {
+		for _, recipe := range graph[element] {
+			other := recipe.Ingredient1
+			if other == element {
+				other = recipe.Ingredient2
+			}
+			if !f.closed(other, f.level-1) || isBaseElement(recipe.Result) {
+				continue
+			}
+			if _, exists := f.depth[recipe.Result]; exists {
+				continue
+			}
+			if current, exists := next[recipe.Result]; !exists || getRecipeID(recipe) < getRecipeID(current) {
+				next[recipe.Result] = recipe
+			}
+		}
+	}
+	f.frontier = f.frontier[:0]
+	for element, recipe := range next {
+		f.depth[element] = f.level
+		f.parent[element] = recipe
+		f.frontier = append(f.frontier, element)
+	}
+	sort.Strings(f.frontier)
+	return len(f.frontier)
+}
+
+// legacyBDSBackward adalah ekspansi AND-OR mundur dari target. Lapisan ke-i berisi sub-goal dengan sisa
+// kedalaman bound-i; sub-goal yang belum tertutup closure maju dibuka lewat semua resepnya, dan
+// kedua bahan resep menjadi sub-goal di lapisan berikutnya (keduanya harus terpenuhi).
+type legacyBDSBackward struct {
+	bound  int
+	layers [][]string
+	solved []map[string]bool
+	chosen []map[string]Recipe
+}
+
+// legacyBDSRecipesFor mengurutkan resep berdasarkan ID agar pilihan resep deterministik.
+func legacyBDSRecipesFor(element string, recipesByResult map[string][]Recipe, cache map[string][]Recipe) []Recipe {
+	if recipes, exists := cache[element]; exists {
+		return recipes
+	}
+	recipes := append([]Recipe(nil), recipesByResult[element]...)
+	sort.Slice(recipes, func(i, j int) bool { return getRecipeID(recipes[i]) < getRecipeID(recipes[j]) })
+	cache[element] = recipes
+	return recipes
+}
+
+// legacyExpandBackward membuka depth lapisan mundur untuk batas kedalaman bound. Sub-goal di lapisan terakhir
+// adalah daun terbuka yang hanya bisa ditutup oleh closure maju. Mengembalikan jumlah sub-goal yang dibuka.
+func legacyExpandBackward(target string, bound, depth int, forward *legacyBDSForward, recipesFor func(string) []Recipe) (*legacyBDSBackward, int) {
+	backward := &legacyBDSBackward{bound: bound, layers: [][]string{{target}}}
+	opened := 0
+	for i := 0; i < depth; i++ {
+		budget := bound - i
+		seen := make(map[string]bool)
+		var next []string
+		for _, element := range backward.layers[i] {
+			if forward.closed(element, budget) {
+				continue
+			}
+			opened++
+			for _, recipe := range recipesFor(element) {
+				for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+					if !isBaseElement(ingredient) && !seen[ingredient] {
+						seen[ingredient] = true
+						next = append(next, ingredient)
+					}
+				}
+			}
+		}
+		sort.Strings(next)
+		backward.layers = append(backward.layers, next)
+	}
+
+	// Evaluasi dari lapisan terdalam: sub-goal terpenuhi jika tertutup closure maju, atau ada resep yang
+	// kedua bahannya terpenuhi di lapisan berikutnya.
+	backward.solved = make([]map[string]bool, len(backward.layers))
+	backward.chosen = make([]map[string]Recipe, len(backward.layers))
+	for i := len(backward.layers) - 1; i >= 0; i-- {
+		budget := bound - i
+		backward.solved[i] = make(map[string]bool)
+		backward.chosen[i] = make(map[string]Recipe)
+		for _, element := range backward.layers[i] {
+			if forward.closed(element, budget) {
+				backward.solved[i][element] = true
+				continue
+			}
+			if i == len(backward.layers)-1 {
+				continue
+			}
+			for _, recipe := range recipesFor(element) {
+				if backward.met(recipe.Ingredient1, i+1) && backward.met(recipe.Ingredient2, i+1) {
+					backward.solved[i][element] = true
+					backward.chosen[i][element] = recipe
+					break
+				}
+			}
+		}
+	}
+	return backward, opened
+}
+
+func (b *legacyBDSBackward) met(element string, layer int) bool {
+	return isBaseElement(element) || b.solved[layer][element]
+}
+
+// openLeaves mengembalikan daun terbuka di lapisan terakhir yang belum bertemu closure maju.
+func (b *legacyBDSBackward) openLeaves(forward *legacyBDSForward) int {
+	last := len(b.layers) - 1
+	open := 0
+	for _, element := range b.layers[last] {
+		if !forward.closed(element, b.bound-last) {
+			open++
+		}
+	}
+	return open
+}
+
+// plan menggabungkan pilihan resep mundur dengan resep closure maju menjadi satu resep per elemen.
+// Elemen yang diminta pada beberapa lapisan memakai resep dari lapisan dengan sisa kedalaman terkecil;
+// resep itu juga memenuhi lapisan lain karena sisa kedalamannya lebih longgar.
+func (b *legacyBDSBackward) plan(target string, forward *legacyBDSForward) []Recipe {
+	recipeParent := make(map[string]Recipe)
+	budgets := make(map[string]int)
+	var visit func(element string, layer int)
+	visit = func(element string, layer int) {
+		budget := b.bound - layer
+		if isBaseElement(element) {
+			return
+		}
+		if current, exists := budgets[element]; exists && current <= budget {
+			return
+		}
+		budgets[element] = budget
+		if forward.closed(element, budget) {
+			recipe := forward.parent[element]
+			recipeParent[element] = recipe
+			visit(recipe.Ingredient1, b.bound-forward.depth[element]+1)
+			visit(recipe.Ingredient2, b.bound-forward.depth[element]+1)
+			return
+		}
+		recipe := b.chosen[layer][element]
+		recipeParent[element] = recipe
+		visit(recipe.Ingredient1, layer+1)
+		visit(recipe.Ingredient2, layer+1)
+	}
+	visit(target, 0)
+	return buildRecipePath(recipeParent, target, budgets)
+}
+
+// legacyFindPathBDS adalah FindPathBDS sebelum memakai CompactGraph (closure maju, lapisan mundur, dan memo
+// berbasis map nama), tanpa log. Disimpan untuk membandingkan hasil dan benchmark dengan versi ID integer.
+// Urutan langkahnya memakai sisa kedalaman seperti versi lama, jadi perbandingan memakai planIdentifier.
+func legacyFindPathBDS(targetElement string) ([]Recipe, int, error) {
+	recipesByResult := GetRecipeMap()
+	alchemyGraph := GetAlchemyGraph()
+	if recipesByResult == nil || alchemyGraph == nil {
+		return nil, 0, errors.New("data resep/graf belum diinisialisasi")
+	}
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+
+	recipeCache := make(map[string][]Recipe)
+	recipesFor := func(element string) []Recipe { return legacyBDSRecipesFor(element, recipesByResult, recipeCache) }
+
+	forward := newLegacyBDSForward()
+	nodesVisited := len(forward.frontier)
+	backwardDepth := 0
+	backwardFrontier := 1
+
+	for {
+		// Sisi dengan frontier lebih kecil yang diperbesar. Sisi maju tetap diperbesar jika sisi mundur sudah
+		// lebih dalam atau tidak punya daun terbuka, supaya closure maju pasti jenuh untuk target yang mustahil.
+		if len(forward.frontier) <= backwardFrontier || backwardFrontier == 0 || backwardDepth > forward.level {
+			added := forward.grow(alchemyGraph)
+			nodesVisited += added
+			if added == 0 {
+				// Closure maju sudah berisi semua elemen yang bisa dibuat; jika target ada di dalamnya,
+				// pencarian sudah berhasil pada langkah sebelumnya.
+				return nil, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+			}
+		} else {
+			backwardDepth++
+		}
+
+		bound := forward.level + backwardDepth
+		backward, opened := legacyExpandBackward(targetElement, bound, backwardDepth, forward, recipesFor)
+		nodesVisited += opened
+		if backward.solved[0][targetElement] {
+			path := backward.plan(targetElement, forward)
+			return path, nodesVisited, nil
+		}
+		backwardFrontier = backward.openLeaves(forward)
+	}
+}
+
+func TestCompactBDSMatchesLegacyBDS(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	for _, element := range elements {
+		path, nodes, err := FindPathBDS(element)
+		legacyPath, legacyNodes, legacyErr := legacyFindPathBDS(element)
+		if (err == nil) != (legacyErr == nil) || nodes != legacyNodes || planIdentifier(path) != planIdentifier(legacyPath) {
+			t.Errorf("BDS %s berbeda dari versi lama: %d node %v, lama %d node %v", element, nodes, path, legacyNodes, legacyPath)
+		}
+	}
+}
+
+func BenchmarkLegacyFindPathBDS(b *testing.B) {
+	benchmarkShortest(b, legacyFindPathBDS)
+}
@@ -0,0 +1,72 @@
+// src/backend/bds_test.go
+package main
+
+import "testing"
+
+func TestBDSFindsCompleteTierDepthPlans(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	tiers, _ := calculateElementTiers(flattenRecipeMap(GetRecipeMap()), baseElements)
+	makeable := makeableElementSet(flattenRecipeMap(GetRecipeMap()), baseElements)
+	for _, element := range elements {
+		path, _, err := FindPathBDS(element)
+		if !makeable[element] {
+			if err == nil {
+				t.Errorf("bds: %s tidak bisa dibuat, tetapi jalur ditemukan", element)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("bds: tidak ada jalur ke %s: %v", element, err)
+			continue
+		}
+		// ValidatePath memastikan rencana lengkap: setiap bahan sudah tersedia sebelum dipakai dan resep terakhir menghasilkan target.
+		if err := ValidatePath(element, path); err != nil {
+			t.Errorf("bds: %v", err)
+			continue
+		}
+		if pathDepth(path) != tiers[element] {
+			t.Errorf("bds: kedalaman %s = %d, tier %d", element, pathDepth(path), tiers[element])
+		}
+	}
+}
+
+func TestBDSIsDeterministic(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range benchmarkTargets {
+		first, firstNodes, _ := FindPathBDS(element)
+		second, secondNodes, _ := FindPathBDS(element)
+		if planIdentifier(first) != planIdentifier(second) || firstNodes != secondNodes {
+			t.Errorf("bds: hasil untuk %s berubah antar pemanggilan", element)
+		}
+	}
+}
+
+func TestBDSPlanStepsFollowDepthOrder(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range benchmarkTargets {
+		path, _, err := FindPathBDS(element)
+		if err != nil {
+			t.Fatalf("bds: tidak ada jalur ke %s: %v", element, err)
+		}
+		depths := make(map[string]int)
+		previous := 0
+		for i, recipe := range path {
+			depth := 1 + max(depths[recipe.Ingredient1], depths[recipe.Ingredient2])
+			depths[recipe.Result] = depth
+			if depth < previous {
+				t.Errorf("bds: langkah %d untuk %s berkedalaman %d setelah langkah berkedalaman %d", i+1, element, depth, previous)
+			}
+			previous = depth
+		}
+	}
+}
@@ -0,0 +1,321 @@
+// src/backend/beam.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	defaultBeamWidth  = 64
+	maxBeamWidth      = 8192
+	defaultBeamBudget = time.Second
+	maxBeamBudget     = 30 * time.Second
+	// Waktu hanya diperiksa setiap sekian ekspansi agar time.Now tidak mendominasi.
+	beamClockInterval = 256
+)
+
+func init() {
+	RegisterSearcher(beamSearcher{})
+}
+
+// BeamOptions mengatur lebar beam awal dan batas waktu; nilai nol berarti default.
+type BeamOptions struct {
+	Width  int
+	Budget time.Duration
+}
+
+func (o BeamOptions) withDefaults() BeamOptions {
+	if o.Width <= 0 {
+		o.Width = defaultBeamWidth
+	}
+	if o.Width > maxBeamWidth {
+		o.Width = maxBeamWidth
+	}
+	if o.Budget <= 0 {
+		o.Budget = defaultBeamBudget
+	}
+	if o.Budget > maxBeamBudget {
+		o.Budget = maxBeamBudget
+	}
+	return o
+}
+
+// BeamImprovement mencatat hasil terbaik sementara setiap kali satu putaran beam memperbaikinya.
+type BeamImprovement struct {
+	Round         int   `json:"round"`
+	Width         int   `json:"width"`
+	ElapsedMicros int64 `json:"elapsedMicros"`
+	Solutions     int   `json:"solutions"`
+	BestSize      int   `json:"bestSize"`
+	WorstSize     int   `json:"worstSize"`
+}
+
+// BeamReport menjelaskan seberapa baik hasil beam. LowerBound adalah ukuran pohon resep terkecil untuk target
+// (heuristik minSizes yang sama dengan A* dan k-best), jadi GapRatio 1 berarti hasil terbaik sudah minimum.
+// TierLowerBound adalah batas bawah yang lebih longgar dari tier target: pohon dengan kedalaman tier
+// memerlukan paling sedikit satu langkah di setiap lapisan kedalaman.
+type BeamReport struct {
+	Width        int   `json:"width"`
+	BudgetMillis int64 `json:"budgetMillis"`
+	Rounds       int   `json:"rounds"`
+	TimedOut     bool  `json:"timedOut"`
+	// Proven berarti hasilnya pasti k pohon terkecil: putaran terakhir tidak memotong state karena lebar
+	// beam, atau pohon ke-k sudah seukuran pohon minimum.
+	Proven         bool              `json:"proven"`
+	LowerBound     int               `json:"lowerBound"`
+	TierLowerBound int               `json:"tierLowerBound"`
+	BestSize       int               `json:"bestSize"`
+	GapRatio       float64           `json:"gapRatio"`
+	Improvements   []BeamImprovement `json:"improvements"`
+}
+
+type BeamResult struct {
+	Paths     [][]Recipe
+	TreeSizes []int
+	Exhausted bool
+	Report    BeamReport
+}
+
+type beamSolution struct {
+	path   []Recipe
+	size   int
+	planID string
+}
+
+// FindBeamPaths adalah beam search anytime atas pohon resep parsial (representasi yang sama dengan k-best).
+// Setiap putaran menyimpan paling banyak width pohon parsial per lapisan, diurutkan berdasarkan ukuran ditambah
+// ukuran minimum subpohon yang masih terbuka. Pohon yang selesai dikumpulkan dan k terkecil dipertahankan;
+// pohon parsial yang estimasinya tidak lebih kecil dari pohon ke-k tidak diteruskan. Lebar beam digandakan
+// setiap putaran sampai tidak ada lagi yang terpotong, batas waktu habis, atau lebar maksimum tercapai.
+func FindBeamPaths(targetElement string, k int, options BeamOptions) (BeamResult, int, error) {
+	options = options.withDefaults()
+	tracef("Beam: Mencari %d pohon resep untuk %s (lebar %d, batas %v)\n", k, targetElement, options.Width, options.Budget)
+	dataset := getDatasetState()
+	recipesByResult := dataset.recipeMap
+	if recipesByResult == nil {
+		return BeamResult{}, 0, errors.New("map resep belum diinisialisasi")
+	}
+	if k <= 0 {
+		return BeamResult{}, 0, errors.New("jumlah resep minimal harus 1")
+	}
+	report := BeamReport{Width: options.Width, BudgetMillis: options.Budget.Milliseconds()}
+	if isBaseElement(targetElement) {
+		return BeamResult{Paths: [][]Recipe{{}}, TreeSizes: []int{0}, Exhausted: k > 1, Report: report}, 0, nil
+	}
+
+	heuristics := getAStarHeuristics(dataset)
+	minSizes := heuristics.minSizes
+	rootSize, reachable := minSizes[targetElement]
+	if !reachable {
+		return BeamResult{Report: report}, 0, fmt.Errorf("elemen '%s' tidak dapat dibuat dari elemen dasar", targetElement)
+	}
+	report.LowerBound = rootSize
+	report.TierLowerBound = heuristics.tiers[targetElement]
+
+	recipeCache := make(map[string][]Recipe)
+	recipesFor := func(element string) []Recipe {
+		if recipes, exists := recipeCache[element]; exists {
+			return recipes
+		}
+		recipes := sortedRecipesFor(element, recipesByResult, minSizes)
+		recipeCache[element] = recipes
+		return recipes
+	}
+
+	start := time.Now()
+	deadline := start.Add(options.Budget)
+	var kept []beamSolution
+	keptIDs := make(map[string]bool)
+	nodesVisited := 0
+
+	// worst mengembalikan ukuran pohon ke-k; state dengan estimasi sebesar itu tidak bisa memperbaiki hasil.
+	worst := func() (int, bool) {
+		if len(kept) < k {
+			return 0, false
+		}
+		return kept[len(kept)-1].size, true
+	}
+
+	for width := options.Width; ; width *= 2 {
+		report.Rounds++
+		pruned, timedOut := false, false
+		var found []beamSolution
+		seq := 0
+		beam := []*partialTree{{estimate: rootSize, open: []openLeaf{{element: targetElement}}}}
+
+		for len(beam) > 0 && !timedOut {
+			var children []*partialTree
+			for _, state := range beam {
+				nodesVisited++
+				if nodesVisited%beamClockInterval == 0 && time.Now().After(deadline) {
+					timedOut = true
+					break
+				}
+				leaf := state.open[len(state.open)-1]
+				rest := state.open[:len(state.open)-1]
+				ancestors := &ancestorChain{element: leaf.element, parent: leaf.ancestors}
+				for _, recipe := range recipesFor(leaf.element) {
+					if ancestors.contains(recipe.Ingredient1) || ancestors.contains(recipe.Ingredient2) {
+						continue
+					}
+					estimate := state.estimate - minSizes[leaf.element] + 1 + minSizes[recipe.Ingredient1] + minSizes[recipe.Ingredient2]
+					if bound, full := worst(); full && estimate >= bound {
+						continue
+					}
+					open := make([]openLeaf, len(rest), len(rest)+2)
+					copy(open, rest)
+					for _, ingredient := range []string{recipe.Ingredient2, recipe.Ingredient1} {
+						if !isBaseElement(ingredient) {
+							open = append(open, openLeaf{element: ingredient, ancestors: ancestors})
+						}
+					}
+					child := &partialTree{
+						size:     state.size + 1,
+						estimate: estimate,
+						choices:  &treeChoice{recipe: recipe, prev: state.choices},
+						open:     open,
+						seq:      seq,
+					}
+					seq++
+					if len(open) == 0 {
+						path := flattenTreeChoices(targetElement, child.choices)
+						found = append(found, beamSolution{path: path, size: child.size, planID: planIdentifier(path)})
+						continue
+					}
+					children = append(children, child)
+				}
+			}
+			sort.Slice(children, func(i, j int) bool { return partialTreeQueue(children).Less(i, j) })
+			if len(children) > width {
+				pruned = true
+				children = children[:width]
+			}
+			beam = children
+		}
+
+		if improved := mergeBeamSolutions(&kept, keptIDs, found, k); improved {
+			report.Improvements = append(report.Improvements, BeamImprovement{
+				Round:         report.Rounds,
+				Width:         width,
+				ElapsedMicros: time.Since(start).Microseconds(),
+				Solutions:     len(kept),
+				BestSize:      kept[0].size,
+				WorstSize:     kept[len(kept)-1].size,
+			})
+			tracef("Beam: Putaran %d (lebar %d): %d pohon, terbaik %d, terburuk %d\n", report.Rounds, width, len(kept), kept[0].size, kept[len(kept)-1].size)
+		}
+
+		if timedOut {
+			report.TimedOut = true
+			break
+		}
+		if !pruned {
+			report.Proven = true
+			break
+		}
+		// Pohon ke-k sudah sebesar pohon minimum, jadi tidak ada yang bisa lebih kecil lagi.
+		if bound, full := worst(); full && bound == rootSize {
+			report.Proven = true
+			break
+		}
+		if width >= maxBeamWidth {
+			break
+		}
+		if time.Now().After(deadline) {
+			report.TimedOut = true
+			break
+		}
+	}
+
+	result := BeamResult{Report: report}
+	for _, solution := range kept {
+		result.Paths = append(result.Paths, solution.path)
+		result.TreeSizes = append(result.TreeSizes, solution.size)
+	}
+	result.Exhausted = report.Proven && len(kept) < k
+	if len(kept) == 0 {
+		return result, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	result.Report.BestSize = kept[0].size
+	if report.LowerBound > 0 {
+		result.Report.GapRatio = float64(kept[0].size) / float64(report.LowerBound)
+	}
+	tracef("Beam: Selesai setelah %d putaran dan %d node; terbaik %d (batas bawah %d).\n", report.Rounds, nodesVisited, kept[0].size, report.LowerBound)
+	return result, nodesVisited, nil
+}
+
+// mergeBeamSolutions menambahkan pohon baru ke k pohon terkecil yang disimpan dan melaporkan apakah
+// himpunan itu berubah. Urutan ukuran lalu ID rencana membuat hasil stabil antar putaran.
+func mergeBeamSolutions(kept *[]beamSolution, keptIDs map[string]bool, found []beamSolution, k int) bool {
+	changed := false
+	for _, solution := range found {
+		if keptIDs[solution.planID] {
+			continue
+		}
+		if len(*kept) >= k && solution.size >= (*kept)[len(*kept)-1].size {
+			continue
+		}
+		keptIDs[solution.planID] = true
+		*kept = append(*kept, solution)
+		sort.Slice(*kept, func(i, j int) bool {
+			if (*kept)[i].size != (*kept)[j].size {
+				return (*kept)[i].size < (*kept)[j].size
+			}
+			return (*kept)[i].planID < (*kept)[j].planID
+		})
+		if len(*kept) > k {
+			delete(keptIDs, (*kept)[k].planID)
+			*kept = (*kept)[:k]
+		}
+		changed = true
+	}
+	return changed
+}
+
+// beamSearcher mendaftarkan beam search; berbeda dari funcSearcher karena menerima SearchOptions.
+type beamSearcher struct {
+	options BeamOptions
+}
+
+func (s beamSearcher) Name() string { return "beam" }
+func (s beamSearcher) Description() string {
+	return "Beam search anytime dengan lebar dan batas waktu yang bisa diatur; membandingkan hasil dengan ukuran pohon minimum"
+}
+func (s beamSearcher) Capabilities() SearchCapabilities {
+	return SearchCapabilities{Modes: []string{"shortest", "multiple"}, ReportsExhausted: true}
+}
+
+func (s beamSearcher) Shortest(target string) (SearchResult, error) {
+	return s.Multiple(target, 1)
+}
+
+func (s beamSearcher) Multiple(target string, maxRecipes int) (SearchResult, error) {
+	result, nodesVisited, err := FindBeamPaths(target, maxRecipes, s.options)
+	return SearchResult{Paths: result.Paths, NodesVisited: nodesVisited, Exhausted: result.Exhausted, Beam: &result.Report}, err
+}
+
+// Pencarian berjalan dalam satu goroutine berapa pun nilai max, tetapi memori mengikuti lebar beam dan
+// lamanya slot dipakai mengikuti batas waktu. Biaya 1 untuk lebar dan batas waktu default, naik sebanding
+// dengan keduanya; beam selebar dan selama mungkin memakai seluruh kapasitas (biaya dibatasi semaphore).
+func (s beamSearcher) EstimateCost(mode string, maxRecipes int) int64 {
+	options := s.options.withDefaults()
+	cost := int64(options.Width) * int64(options.Budget) / (int64(defaultBeamWidth) * int64(defaultBeamBudget))
+	if cost < 1 {
+		return 1
+	}
+	return cost
+}
+
+func (s beamSearcher) WithOptions(options SearchOptions) Searcher {
+	return beamSearcher{options: BeamOptions{Width: options.BeamWidth, Budget: options.TimeBudget}}
+}
+
+func (s beamSearcher) OptionParameters() []SearchParameter {
+	return []SearchParameter{
+		{Name: "beamWidth", Description: fmt.Sprintf("Lebar beam awal, digandakan setiap putaran (default %d, maksimum %d)", defaultBeamWidth, maxBeamWidth)},
+		{Name: "budgetMs", Description: fmt.Sprintf("Batas waktu pencarian dalam milidetik (default %d, maksimum %d)", defaultBeamBudget.Milliseconds(), maxBeamBudget.Milliseconds())},
+	}
+}
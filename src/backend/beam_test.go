@@ -0,0 +1,94 @@
+// src/backend/beam_test.go
+package main
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBeamMatchesKBestWhenProven(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range benchmarkTargets {
+		kBest, _, err := FindKBestPaths(element, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, _, err := FindBeamPaths(element, 10, BeamOptions{Width: 16, Budget: 10 * time.Second})
+		if err != nil {
+			t.Errorf("beam: tidak ada jalur ke %s: %v", element, err)
+			continue
+		}
+		for i, path := range result.Paths {
+			if err := ValidatePath(element, path); err != nil {
+				t.Errorf("beam: jalur #%d: %v", i+1, err)
+			}
+			if i > 0 && result.TreeSizes[i] < result.TreeSizes[i-1] {
+				t.Errorf("beam: ukuran pohon %s tidak terurut: %v", element, result.TreeSizes)
+			}
+		}
+		report := result.Report
+		if !report.Proven {
+			t.Errorf("beam: hasil %s belum terbukti setelah %d putaran", element, report.Rounds)
+			continue
+		}
+		if len(result.TreeSizes) != len(kBest.TreeSizes) {
+			t.Errorf("beam: %s menghasilkan %d pohon, k-best %d", element, len(result.TreeSizes), len(kBest.TreeSizes))
+			continue
+		}
+		for i := range kBest.TreeSizes {
+			if result.TreeSizes[i] != kBest.TreeSizes[i] {
+				t.Errorf("beam: ukuran pohon %s = %v, k-best %v", element, result.TreeSizes, kBest.TreeSizes)
+				break
+			}
+		}
+		if len(report.Improvements) == 0 || report.BestSize != result.TreeSizes[0] || report.TierLowerBound <= 0 {
+			t.Errorf("beam: laporan %s tidak lengkap: %+v", element, report)
+		}
+		// Pohon pertama k-best adalah pohon terkecil, jadi batas bawah ukuran pohon tercapai tepat.
+		if report.LowerBound != kBest.TreeSizes[0] || report.GapRatio != 1 || report.TierLowerBound > report.LowerBound {
+			t.Errorf("beam: batas bawah %s salah: %+v", element, report)
+		}
+	}
+}
+
+func TestBeamStopsAtTimeBudget(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	start := time.Now()
+	result, _, _ := FindBeamPaths("Computer", 500, BeamOptions{Width: 1, Budget: 50 * time.Millisecond})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("beam berjalan %v dengan batas 50ms", elapsed)
+	}
+	// Hasil anytime: pohon yang sudah ditemukan tetap dikembalikan ketika waktu habis.
+	if !result.Report.TimedOut || len(result.Paths) == 0 || result.Exhausted {
+		t.Errorf("beam seharusnya berhenti karena waktu dengan hasil sementara: %d jalur, %+v", len(result.Paths), result.Report)
+	}
+}
+
+func TestBeamCostScalesWithWidthAndBudget(t *testing.T) {
+	defaults := beamSearcher{}.EstimateCost("multiple", 50)
+	wide := withSearchOptions(beamSearcher{}, SearchOptions{BeamWidth: 4 * defaultBeamWidth}).EstimateCost("multiple", 50)
+	long := withSearchOptions(beamSearcher{}, SearchOptions{BeamWidth: 4 * defaultBeamWidth, TimeBudget: 10 * defaultBeamBudget}).EstimateCost("multiple", 50)
+	if defaults != 1 || wide != 4 || long != 40 {
+		t.Errorf("biaya beam seharusnya 1, 4, 40; didapat %d, %d, %d", defaults, wide, long)
+	}
+	if cost := estimateSearchCost("beam", "multiple", 50, SearchOptions{BeamWidth: maxBeamWidth, TimeBudget: maxBeamBudget}); cost < defaultSearchLimits.MaxConcurrentCost {
+		t.Errorf("beam maksimum seharusnya memakai seluruh kapasitas, biaya %d", cost)
+	}
+}
+
+func TestParseSearchOptions(t *testing.T) {
+	options, err := parseSearchOptions(url.Values{"beamWidth": {"32"}, "budgetMs": {"250"}})
+	if err != nil || options.BeamWidth != 32 || options.TimeBudget != 250*time.Millisecond {
+		t.Errorf("options salah: %+v %v", options, err)
+	}
+	for _, query := range []url.Values{{"beamWidth": {"0"}}, {"beamWidth": {"abc"}}, {"budgetMs": {"-5"}}, {"budgetMs": {"999999"}}} {
+		if _, err := parseSearchOptions(query); err == nil {
+			t.Errorf("query %v seharusnya ditolak", query)
+		}
+	}
+}
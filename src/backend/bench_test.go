@@ -0,0 +1,98 @@
+// src/backend/bench_test.go
+package main
+
+import "testing"
+
+// Salinan dataset di testdata dipakai agar hasil benchmark tidak berubah ketika data hasil scraping diperbarui.
+const testDataDir = "testdata"
+
+var benchmarkTargets = []string{"Brick", "Human", "Computer", "Dinosaur", "Picnic"}
+
+func loadTestDataset(tb testing.TB) {
+	tb.Helper()
+	if err := InitData(testDataDir); err != nil {
+		tb.Fatalf("gagal memuat dataset uji: %v", err)
+	}
+	BuildGraph(GetRecipeMap())
+}
+
+// muteTrace membuang log pencarian yang sangat banyak selama pengujian dan pengukuran.
+func muteTrace(tb testing.TB) {
+	tb.Helper()
+	tb.Cleanup(silenceTrace())
+}
+
+func benchmarkShortest(b *testing.B, find func(string) ([]Recipe, int, error)) {
+	loadTestDataset(b)
+	for _, target := range benchmarkTargets {
+		b.Run(target, func(b *testing.B) {
+			muteTrace(b)
+			b.ReportAllocs()
+			var nodes int
+			for i := 0; i < b.N; i++ {
+				ResetCaches()
+				path, visited, err := find(target)
+				if err != nil || len(path) == 0 {
+					b.Fatalf("tidak ada jalur ke %s: %v", target, err)
+				}
+				nodes = visited
+			}
+			b.ReportMetric(float64(nodes), "nodes/op")
+		})
+	}
+}
+
+func benchmarkMultiple(b *testing.B, find func(string, int) ([][]Recipe, int, error), maxRecipes int) {
+	loadTestDataset(b)
+	for _, target := range benchmarkTargets {
+		b.Run(target, func(b *testing.B) {
+			muteTrace(b)
+			b.ReportAllocs()
+			var nodes int
+			for i := 0; i < b.N; i++ {
+				ResetCaches()
+				paths, visited, err := find(target, maxRecipes)
+				if err != nil || len(paths) == 0 {
+					b.Fatalf("tidak ada jalur ke %s: %v", target, err)
+				}
+				nodes = visited
+			}
+			b.ReportMetric(float64(nodes), "nodes/op")
+		})
+	}
+}
+
+func BenchmarkFindPathBFS(b *testing.B) {
+	benchmarkShortest(b, FindPathBFS)
+}
+
+func BenchmarkFindPathDFS(b *testing.B) {
+	benchmarkShortest(b, FindPathDFS)
+}
+
+func BenchmarkFindPathBDS(b *testing.B) {
+	benchmarkShortest(b, FindPathBDS)
+}
+
+func BenchmarkFindPathAStar(b *testing.B) {
+	benchmarkShortest(b, FindPathAStar)
+}
+
+func BenchmarkFindPathIDDFS(b *testing.B) {
+	benchmarkShortest(b, func(target string) ([]Recipe, int, error) {
+		path, _, nodesVisited, err := FindPathIDDFS(target)
+		return path, nodesVisited, err
+	})
+}
+
+func BenchmarkFindMultiplePathsBFS(b *testing.B) {
+	benchmarkMultiple(b, FindMultiplePathsBFS, 3)
+}
+
+func BenchmarkFindMultiplePathsDFS(b *testing.B) {
+	benchmarkMultiple(b, FindMultiplePathsDFS, 3)
+}
+
+func BenchmarkFindMultiplePathsBDS(b *testing.B) {
+	benchmarkMultiple(b, FindMultiplePathsBDS, 3)
+}
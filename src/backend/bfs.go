@@ -12,6 +12,75 @@ import (
 	"sync/atomic"
 )
 
+func init() {
+	RegisterSearcher(bfsSearcher{})
+}
+
+// bfsSearcher mendaftarkan BFS. Berbeda dari funcSearcher karena bisa diberi cache jalur sendiri lewat
+// SearchOptions.PathCache (dipakai compare agar tidak mengosongkan cache bersama).
+type bfsSearcher struct {
+	cache *bfsPathCache
+}
+
+func (s bfsSearcher) Name() string { return "bfs" }
+func (s bfsSearcher) Description() string {
+	return "Breadth First Search dari elemen dasar; mode multiple memakai worker paralel"
+}
+func (s bfsSearcher) Capabilities() SearchCapabilities {
+	return SearchCapabilities{Modes: []string{"shortest", "multiple"}}
+}
+
+func (s bfsSearcher) Shortest(target string) (SearchResult, error) {
+	path, nodesVisited, err := findPathBFS(target, s.cache)
+	result := SearchResult{NodesVisited: nodesVisited}
+	if path != nil {
+		result.Paths = [][]Recipe{path}
+	}
+	return result, err
+}
+
+func (s bfsSearcher) Multiple(target string, maxRecipes int) (SearchResult, error) {
+	paths, nodesVisited, err := findMultiplePathsBFS(target, maxRecipes, s.cache)
+	return SearchResult{Paths: paths, NodesVisited: nodesVisited}, err
+}
+
+// 3 worker per kombinasi target + NumCPU*2 worker tambahan
+func (s bfsSearcher) EstimateCost(mode string, maxRecipes int) int64 {
+	if mode != "multiple" || maxRecipes <= 1 {
+		return 1
+	}
+	return int64(3*maxRecipes + runtime.NumCPU()*2)
+}
+
+func (s bfsSearcher) WithOptions(options SearchOptions) Searcher {
+	return bfsSearcher{cache: options.PathCache}
+}
+
+func (s bfsSearcher) OptionParameters() []SearchParameter { return nil }
+
+// bfsPathCache menyimpan jalur BFS terpendek per target untuk satu dataset.
+type bfsPathCache struct {
+	mu    sync.RWMutex
+	paths map[string][]Recipe
+}
+
+func newBFSPathCache() *bfsPathCache {
+	return &bfsPathCache{paths: make(map[string][]Recipe)}
+}
+
+func (c *bfsPathCache) get(target string) ([]Recipe, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	path, exists := c.paths[target]
+	return path, exists
+}
+
+func (c *bfsPathCache) put(target string, path []Recipe) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paths[target] = path
+}
+
 var baseElements = []string{"Air", "Earth", "Fire", "Water"}
 
 var baseElementMap = map[string]bool{
@@ -25,112 +94,95 @@ func isBaseElement(name string) bool {
 	return baseElementMap[name]
 }
 
-var (
-	bfsPathCacheMutex sync.RWMutex
-)
-
+// FindPathBFS menelusuri elemen per antrean dari elemen dasar. Setiap elemen yang dikeluarkan dipasangkan
+// dengan elemen yang sudah ditemukan sebelum elemen itu diproses, dengan urutan pasangan berdasarkan nama
+// bahan lalu nama hasil. Semua struktur memakai ID integer dari CompactGraph.
 func FindPathBFS(targetElement string) ([]Recipe, int, error) {
-	fmt.Printf("Finding BFS shortest path to: %s\n", targetElement)
-	graph := GetAlchemyGraph()
-	if graph == nil {
+	return findPathBFS(targetElement, nil)
+}
+
+// findPathBFS memakai cache yang diberikan, atau cache bersama jika nil. Cache bersama diambil bersama
+// grafnya dalam satu lock supaya jalur dari graf lama tidak tersimpan ke cache dataset baru.
+func findPathBFS(targetElement string, cache *bfsPathCache) ([]Recipe, int, error) {
+	tracef("Finding BFS shortest path to: %s\n", targetElement)
+	var g *CompactGraph
+	if cache == nil {
+		g, cache = getCompactGraphWithBFSCache()
+	} else {
+		g = GetCompactGraph()
+	}
+	if g == nil {
 		return nil, 0, errors.New("alchemy graph not initialized")
 	}
 
-	bfsPathCacheMutex.RLock()
-	if path, exists := bfsPathCache[targetElement]; exists {
-		bfsPathCacheMutex.RUnlock()
-		fmt.Printf("BFS Cache: Path to '%s' found in cache.\n", targetElement)
+	if path, exists := cache.get(targetElement); exists {
+		tracef("BFS Cache: Path to '%s' found in cache.\n", targetElement)
 		return path, 0, nil
 	}
-	bfsPathCacheMutex.RUnlock()
 
 	if isBaseElement(targetElement) {
 		return []Recipe{}, 0, nil
 	}
+	target, exists := g.ID(targetElement)
+	if !exists {
+		target = -1
+	}
 
-	queue := list.New()
-	visited := make(map[string]bool, 1000)
-	elementVisited := make(map[string]bool, 1000)
-	recipeParent := make(map[string]Recipe)
-	discovered := make(map[string]bool, 1000)
-	nodesVisitedCount := 0
-
-	depth := make(map[string]int)
-
-	sortedBaseElements := make([]string, len(baseElements))
-	copy(sortedBaseElements, baseElements)
-	sort.Strings(sortedBaseElements)
-
-	for _, base := range sortedBaseElements {
-		elementVisited[base] = true
-		discovered[base] = true
-		queue.PushBack(base)
-		depth[base] = 0
-
-		fmt.Printf("Enqueue base element: %s\n", base)
+	n := g.NumElements()
+	// discoveredAt adalah urutan penemuan (-1 jika belum); elemen hanya dipasangkan dengan elemen yang
+	// ditemukan sebelum pasangan elemen saat ini mulai diperiksa.
+	discoveredAt := make([]int32, n)
+	depth := make([]int32, n)
+	parent := make([]int32, n)
+	for id := range discoveredAt {
+		discoveredAt[id] = -1
+		parent[id] = -1
+	}
+	queue := make([]int32, 0, n)
+	discovered := int32(0)
+	for _, base := range baseElements {
+		if id, exists := g.ID(base); exists {
+			queue = append(queue, id)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool { return queue[i] < queue[j] })
+	for _, id := range queue {
+		discoveredAt[id] = discovered
+		discovered++
 	}
 
-	for queue.Len() > 0 {
-		currentElement := queue.Remove(queue.Front()).(string)
-		currentDepth := depth[currentElement]
-		fmt.Printf("Dequeue: %s at depth %d\n", currentElement, currentDepth)
+	nodesVisitedCount := 0
+	for head := 0; head < len(queue); head++ {
+		current := queue[head]
 		nodesVisitedCount++
+		snapshot := discovered
 
-		combinableRecipes := graph[currentElement]
-		if len(combinableRecipes) == 0 {
-			continue
-		}
-		discoveredElementsList := make([]string, 0, len(discovered))
-		for element := range discovered {
-			discoveredElementsList = append(discoveredElementsList, element)
-		}
-		sort.Strings(discoveredElementsList)
-
-		for _, otherElement := range discoveredElementsList {
-			pairKey := getPairKey(currentElement, otherElement)
-			if visited[pairKey] {
+		for _, rid := range g.Uses(current) {
+			other := g.Other(rid, current)
+			if discoveredAt[other] < 0 || discoveredAt[other] >= snapshot {
 				continue
 			}
-			visited[pairKey] = true
-			recipes := getRecipes(currentElement, otherElement)
-
-			for _, recipe := range recipes {
-				result := recipe.Result
-
-				if !discovered[result] {
-					discovered[result] = true
-					recipeParent[result] = recipe
-					depth[result] = currentDepth + 1
-					if result == targetElement {
-						fmt.Printf("Target '%s' found!\n", targetElement)
-						path := buildRecipePath(recipeParent, targetElement, depth)
-						bfsPathCacheMutex.Lock()
-						bfsPathCache[targetElement] = path
-						bfsPathCacheMutex.Unlock()
-
-						return path, nodesVisitedCount, nil
-					}
-					if !elementVisited[result] {
-						elementVisited[result] = true
-						queue.PushBack(result)
-						fmt.Printf("Enqueue: %s (from %s + %s) at depth %d\n",
-							result, currentElement, otherElement, depth[result])
-					}
-				}
+			result := g.recipes[rid].result
+			if discoveredAt[result] >= 0 {
+				continue
 			}
+			discoveredAt[result] = discovered
+			discovered++
+			parent[result] = rid
+			depth[result] = depth[current] + 1
+			if result == target {
+				tracef("Target '%s' found!\n", targetElement)
+				path := g.recipePathFromParents(parent, depth, target)
+				cache.put(targetElement, path)
+				return path, nodesVisitedCount, nil
+			}
+			queue = append(queue, result)
 		}
 	}
-	fmt.Printf("Target '%s' cannot be found.\n", targetElement)
+	tracef("Target '%s' cannot be found.\n", targetElement)
 	return nil, nodesVisitedCount, fmt.Errorf("path to element '%s' not found", targetElement)
 }
 
-func getPairKey(a, b string) string {
-	if a > b {
-		return b + ":" + a
-	}
-	return a + ":" + b
-}
-
 func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[string]int) []Recipe {
 	dependencies := make(map[string][]string)
 	elementsNeeded := make(map[string]bool)
@@ -226,30 +278,22 @@ func buildRecipePath(recipeParent map[string]Recipe, target string, depth map[st
 	return result
 }
 
+// getRecipes mengembalikan resep dengan pasangan bahan (a,b) terurut berdasarkan hasil, lewat indeks pasangan.
 func getRecipes(a, b string) []Recipe {
-	graph := GetAlchemyGraph()
-	var result []Recipe
-
-	aRecipes := graph[a]
-	bRecipes := graph[b]
-
-	sourceRecipes := aRecipes
-	if len(bRecipes) < len(aRecipes) {
-		sourceRecipes = bRecipes
+	g := GetCompactGraph()
+	if g == nil {
+		return nil
 	}
-
-	for _, r := range sourceRecipes {
-		if (r.Ingredient1 == a && r.Ingredient2 == b) || (r.Ingredient1 == b && r.Ingredient2 == a) {
-			result = append(result, r)
-		}
+	idA, okA := g.ID(a)
+	idB, okB := g.ID(b)
+	if !okA || !okB {
+		return nil
 	}
-
-	if len(result) > 1 {
-		sort.SliceStable(result, func(i, j int) bool {
-			return result[i].Result < result[j].Result
-		})
+	rids := g.PairRecipes(idA, idB)
+	if len(rids) == 0 {
+		return nil
 	}
-	return result
+	return g.recipesToPath(rids)
 }
 
 func generatePathIdentifier(path []Recipe) string {
@@ -285,10 +329,14 @@ func getUniqueRecipeKey(recipe Recipe) string {
 }
 
 func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
-	fmt.Printf("Finding %d different BFS paths to: %s (Multithreaded)\n", maxRecipes, targetElement)
+	return findMultiplePathsBFS(targetElement, maxRecipes, nil)
+}
 
-	graph := GetAlchemyGraph()
-	if graph == nil {
+func findMultiplePathsBFS(targetElement string, maxRecipes int, cache *bfsPathCache) ([][]Recipe, int, error) {
+	tracef("Finding %d different BFS paths to: %s (Multithreaded)\n", maxRecipes, targetElement)
+
+	g := GetCompactGraph()
+	if g == nil {
 		return nil, 0, errors.New("alchemy graph not initialized")
 	}
 	if maxRecipes <= 0 {
@@ -298,35 +346,44 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 		return [][]Recipe{}, 0, nil
 	}
 
-	uniqueRecipeCombos, allCombinations := getAllUniqueRecipeCombinations(targetElement)
+	uniqueRecipeCombos, allCombinations := getAllUniqueRecipeCombinations(g, targetElement)
 	if uniqueRecipeCombos == 0 {
 		return nil, 0, fmt.Errorf("element '%s' not found in recipe database", targetElement)
 	}
+	target, _ := g.ID(targetElement)
 
-	fmt.Printf("Element '%s' can be created from %d unique ingredient combinations:\n",
+	tracef("Element '%s' can be created from %d unique ingredient combinations:\n",
 		targetElement, uniqueRecipeCombos)
-	for comboKey, recipe := range allCombinations {
-		fmt.Printf("  - %s + %s => %s (key: %s)\n",
+	for comboKey, rid := range allCombinations {
+		recipe := g.Recipe(rid)
+		tracef("  - %s + %s => %s (key: %s)\n",
 			recipe.Ingredient1, recipe.Ingredient2, recipe.Result, comboKey)
 	}
 
 	if uniqueRecipeCombos < maxRecipes {
-		fmt.Printf("Adjusting max paths to %d to match available combinations\n", uniqueRecipeCombos)
+		tracef("Adjusting max paths to %d to match available combinations\n", uniqueRecipeCombos)
 		maxRecipes = uniqueRecipeCombos
 	}
 
 	if maxRecipes == 1 {
-		firstPath, visitCount, err := FindPathBFS(targetElement)
+		firstPath, visitCount, err := findPathBFS(targetElement, cache)
 		if err != nil {
 			return nil, visitCount, err
 		}
 		return [][]Recipe{firstPath}, visitCount, nil
 	}
 
+	// Resep duplikat dengan pasangan bahan yang sama memakai kunci kombinasi yang sama.
+	comboKeyOf := make(map[int32]string)
+	first, last := g.Producers(target)
+	for rid := first; rid < last; rid++ {
+		comboKeyOf[rid] = getUniqueRecipeKey(g.Recipe(rid))
+	}
+
 	foundTargetCombinations := make(map[string]bool)
-	remainingCombinations := make(map[string]Recipe, uniqueRecipeCombos)
-	for comboKey, recipe := range allCombinations {
-		remainingCombinations[comboKey] = recipe
+	remainingCombinations := make(map[string]int32, uniqueRecipeCombos)
+	for comboKey, rid := range allCombinations {
+		remainingCombinations[comboKey] = rid
 	}
 
 	var allFoundPaths [][]Recipe
@@ -338,7 +395,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	pathChan := make(chan []Recipe, maxRecipes)
 	done := atomic.Bool{}
 
-	firstPath, _, firstErr := FindPathBFS(targetElement)
+	firstPath, _, firstErr := findPathBFS(targetElement, cache)
 	if firstErr == nil && len(firstPath) > 0 {
 		pathID := generatePathIdentifier(firstPath)
 
@@ -359,7 +416,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 		delete(remainingCombinations, comboKey)
 		mu.Unlock()
 
-		fmt.Printf("Initial path found for %s via FindPathBFS using ingredients: %s + %s\n",
+		tracef("Initial path found for %s via FindPathBFS using ingredients: %s + %s\n",
 			targetElement, targetRecipe.Ingredient1, targetRecipe.Ingredient2)
 
 		select {
@@ -375,13 +432,46 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 		return isDone || done.Load()
 	}
 
+	// addPath mencatat jalur jika kombinasi bahan target dan jalurnya belum pernah ditemukan.
+	addPath := func(workerID, strategyVariant int, currentPath []Recipe, pathTargetRecipe Recipe) {
+		pathComboKey := getUniqueRecipeKey(pathTargetRecipe)
+		pathID := generatePathIdentifier(currentPath)
+
+		mu.Lock()
+		defer mu.Unlock()
+		isNewCombo := !foundTargetCombinations[pathComboKey]
+		isNewPath := !addedPathIdentifiers[pathID]
+		if !isNewCombo || !isNewPath || len(allFoundPaths) >= maxRecipes {
+			return
+		}
+
+		allFoundPaths = append(allFoundPaths, currentPath)
+		addedPathIdentifiers[pathID] = true
+		foundTargetCombinations[pathComboKey] = true
+		delete(remainingCombinations, pathComboKey)
+
+		tracef("Worker %d: Found path #%d for %s using ingredients: %s + %s (strategy: %d)\n",
+			workerID, len(allFoundPaths), targetElement,
+			pathTargetRecipe.Ingredient1, pathTargetRecipe.Ingredient2,
+			strategyVariant)
+
+		select {
+		case pathChan <- currentPath:
+		default:
+		}
+
+		if len(allFoundPaths) >= maxRecipes || len(foundTargetCombinations) >= uniqueRecipeCombos {
+			done.Store(true)
+		}
+	}
+
 	if len(foundTargetCombinations) < uniqueRecipeCombos && len(allFoundPaths) < maxRecipes {
 		numWorkersPerCombo := 3
 
 		mu.Lock()
-		combinationsToSearch := make([]Recipe, 0, len(remainingCombinations))
-		for _, recipe := range remainingCombinations {
-			combinationsToSearch = append(combinationsToSearch, recipe)
+		combinationsToSearch := make([]int32, 0, len(remainingCombinations))
+		for _, rid := range remainingCombinations {
+			combinationsToSearch = append(combinationsToSearch, rid)
 		}
 		mu.Unlock()
 
@@ -392,10 +482,10 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 				}
 
 				wg.Add(1)
-				go func(workerID int, comboIdx int, targetComboRecipe Recipe) {
+				go func(workerID int, comboIdx int, targetComboRecipe int32) {
 					defer wg.Done()
 
-					comboKey := getUniqueRecipeKey(targetComboRecipe)
+					comboKey := comboKeyOf[targetComboRecipe]
 
 					mu.Lock()
 					alreadyFound := foundTargetCombinations[comboKey]
@@ -406,14 +496,15 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 					}
 
 					strategyVariant := (workerID + comboIdx) % 5
+					comboRecipe := g.Recipe(targetComboRecipe)
 
-					fmt.Printf("Worker %d searching for combo %d: %s + %s => %s (strategy: %d)\n",
+					tracef("Worker %d searching for combo %d: %s + %s => %s (strategy: %d)\n",
 						workerID, comboIdx,
-						targetComboRecipe.Ingredient1, targetComboRecipe.Ingredient2,
-						targetComboRecipe.Result, strategyVariant)
+						comboRecipe.Ingredient1, comboRecipe.Ingredient2,
+						comboRecipe.Result, strategyVariant)
 
 					currentPath := findPathForSpecificCombination(
-						targetElement,
+						g,
 						targetComboRecipe,
 						strategyVariant,
 						&nodesVisitedCount,
@@ -429,43 +520,12 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 							}
 						}
 
-						pathComboKey := getUniqueRecipeKey(foundTargetRecipe)
-						if pathComboKey != comboKey {
-							fmt.Printf("Warning: Worker %d found wrong combination %s instead of %s\n",
+						if pathComboKey := getUniqueRecipeKey(foundTargetRecipe); pathComboKey != comboKey {
+							tracef("Warning: Worker %d found wrong combination %s instead of %s\n",
 								workerID, pathComboKey, comboKey)
 							return
 						}
-
-						pathID := generatePathIdentifier(currentPath)
-
-						mu.Lock()
-						isNewCombo := !foundTargetCombinations[pathComboKey]
-						isNewPath := !addedPathIdentifiers[pathID]
-
-						if isNewCombo && isNewPath && len(allFoundPaths) < maxRecipes {
-							pathCopy := make([]Recipe, len(currentPath))
-							copy(pathCopy, currentPath)
-
-							allFoundPaths = append(allFoundPaths, pathCopy)
-							addedPathIdentifiers[pathID] = true
-							foundTargetCombinations[pathComboKey] = true
-							delete(remainingCombinations, pathComboKey)
-
-							fmt.Printf("Worker %d: Found path #%d for %s using ingredients: %s + %s (strategy: %d)\n",
-								workerID, len(allFoundPaths), targetElement,
-								foundTargetRecipe.Ingredient1, foundTargetRecipe.Ingredient2,
-								strategyVariant)
-
-							select {
-							case pathChan <- pathCopy:
-							default:
-							}
-
-							if len(allFoundPaths) >= maxRecipes || len(foundTargetCombinations) >= uniqueRecipeCombos {
-								done.Store(true)
-							}
-						}
-						mu.Unlock()
+						addPath(workerID, strategyVariant, currentPath, foundTargetRecipe)
 					}
 				}(w, comboIdx, targetRecipe)
 			}
@@ -480,80 +540,45 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 					defer wg.Done()
 
 					strategyVariant := workerID % 5
-					queue := list.New()
-					localVisited := make(map[string]bool)
-					parent := make(map[string]Recipe)
-					discovered := make(map[string]bool)
-
-					startOffset := (workerID * 17) % len(baseElements)
-					for i := 0; i < len(baseElements); i++ {
-						idx := (startOffset + i) % len(baseElements)
-						base := baseElements[idx]
-						queue.PushBack(base)
-						localVisited[base] = true
-						discovered[base] = true
-					}
+					worker := newBFSWorker(g, (workerID*17)%len(baseElements))
 
-					depthMap := make(map[string]int)
-					for _, base := range baseElements {
-						depthMap[base] = 0
-					}
-
-					for queue.Len() > 0 && !shouldStop() {
-						currentElement := queue.Remove(queue.Front()).(string)
-						currentDepth := depthMap[currentElement]
+					for worker.queue.len() > 0 && !shouldStop() {
+						currentElement := worker.queue.popFront()
+						currentDepth := worker.depth[currentElement]
 						nodesVisitedCount.Add(1)
 
 						if nodesVisitedCount.Load()%1000 == 0 {
 							mu.Lock()
 							if len(remainingCombinations) > 0 && len(remainingCombinations) <= 3 {
-								var targetCombo Recipe
-								for _, recipe := range remainingCombinations {
-									targetCombo = recipe
+								var targetCombo int32
+								for _, rid := range remainingCombinations {
+									targetCombo = rid
 									break
 								}
 								mu.Unlock()
 
-								ing1 := targetCombo.Ingredient1
-								ing2 := targetCombo.Ingredient2
-
-								if !discovered[ing1] {
-									queue.PushFront(ing1)
+								recipe := g.recipes[targetCombo]
+								if !worker.discovered[recipe.ingredient1] {
+									worker.queue.pushFront(recipe.ingredient1)
 								}
-								if !discovered[ing2] {
-									queue.PushFront(ing2)
+								if !worker.discovered[recipe.ingredient2] {
+									worker.queue.pushFront(recipe.ingredient2)
 								}
 							} else {
 								mu.Unlock()
 							}
 						}
 
-						combinableElements := make([]string, 0, len(discovered))
-						for elem := range discovered {
-							combinableElements = append(combinableElements, elem)
-						}
-
-						sortElements(combinableElements, strategyVariant, depthMap, workerID)
-
-						for _, otherElement := range combinableElements {
-							pairKey := getPairKey(currentElement, otherElement)
-
-							if localVisited[pairKey] {
-								continue
-							}
-							localVisited[pairKey] = true
-
-							recipes := getRecipes(currentElement, otherElement)
-
-							for _, recipe := range recipes {
+						for _, otherElement := range worker.combinable(currentElement, strategyVariant, workerID) {
+							for _, rid := range g.PairRecipes(currentElement, otherElement) {
 								if shouldStop() {
 									return
 								}
 
-								result := recipe.Result
+								result := g.recipes[rid].result
 								resultDepth := currentDepth + 1
 
-								_, alreadyFound := parent[result]
+								alreadyFound := worker.parent[result] >= 0
 
 								shouldOverride := false
 								if alreadyFound {
@@ -562,25 +587,17 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 								}
 
 								if !alreadyFound || shouldOverride {
-									parent[result] = recipe
-									depthMap[result] = resultDepth
+									worker.parent[result] = rid
+									worker.depth[result] = resultDepth
 								}
 
-								wasNewDiscovery := !discovered[result]
-								discovered[result] = true
-
-								queueIt := wasNewDiscovery
-								if wasNewDiscovery || shouldOverride {
-									if !localVisited[result] || shouldOverride {
-										localVisited[result] = true
-										if queueIt {
-											queue.PushBack(result)
-										}
-									}
+								if !worker.discovered[result] {
+									worker.discovered[result] = true
+									worker.queue.pushBack(result)
 								}
 
-								if result == targetElement {
-									comboKey := getUniqueRecipeKey(recipe)
+								if result == target {
+									comboKey := comboKeyOf[rid]
 
 									mu.Lock()
 									alreadyFoundThisCombo := foundTargetCombinations[comboKey]
@@ -590,7 +607,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 										continue
 									}
 
-									currentPath := buildDiversePath(parent, targetElement, workerID)
+									currentPath := buildDiversePath(g, worker.parent, target, workerID)
 									if len(currentPath) > 0 {
 										var pathTargetRecipe Recipe
 										for _, r := range currentPath {
@@ -599,37 +616,7 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 												break
 											}
 										}
-
-										pathComboKey := getUniqueRecipeKey(pathTargetRecipe)
-										pathID := generatePathIdentifier(currentPath)
-
-										mu.Lock()
-										isNewCombo := !foundTargetCombinations[pathComboKey]
-										isNewPath := !addedPathIdentifiers[pathID]
-
-										if isNewCombo && isNewPath && len(allFoundPaths) < maxRecipes {
-											pathCopy := make([]Recipe, len(currentPath))
-											copy(pathCopy, currentPath)
-
-											allFoundPaths = append(allFoundPaths, pathCopy)
-											addedPathIdentifiers[pathID] = true
-											foundTargetCombinations[pathComboKey] = true
-											delete(remainingCombinations, pathComboKey)
-
-											fmt.Printf("Worker %d: Found path #%d for %s using ingredients: %s + %s (strategy: %d)\n",
-												workerID, len(allFoundPaths), targetElement,
-												pathTargetRecipe.Ingredient1, pathTargetRecipe.Ingredient2,
-												strategyVariant)
-											select {
-											case pathChan <- pathCopy:
-											default:
-											}
-
-											if len(allFoundPaths) >= maxRecipes || len(foundTargetCombinations) >= uniqueRecipeCombos {
-												done.Store(true)
-											}
-										}
-										mu.Unlock()
+										addPath(workerID, strategyVariant, currentPath, pathTargetRecipe)
 									}
 								}
 							}
@@ -655,9 +642,10 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 
 	missingCount := len(remainingCombinations)
 	if missingCount > 0 {
-		fmt.Printf("Warning: %d combinations were never found:\n", missingCount)
-		for comboKey, recipe := range remainingCombinations {
-			fmt.Printf("  - Missing: %s + %s => %s (key: %s)\n",
+		tracef("Warning: %d combinations were never found:\n", missingCount)
+		for comboKey, rid := range remainingCombinations {
+			recipe := g.Recipe(rid)
+			tracef("  - Missing: %s + %s => %s (key: %s)\n",
 				recipe.Ingredient1, recipe.Ingredient2, recipe.Result, comboKey)
 		}
 	}
@@ -667,120 +655,161 @@ func FindMultiplePathsBFS(targetElement string, maxRecipes int) ([][]Recipe, int
 	mu.Unlock()
 
 	if foundCount == 0 && !isBaseElement(targetElement) {
-		fmt.Printf("BFS Multiple: No paths found for '%s'.\n", targetElement)
+		tracef("BFS Multiple: No paths found for '%s'.\n", targetElement)
 		return nil, int(nodesVisitedCount.Load()), fmt.Errorf("path to element '%s' not found", targetElement)
 	}
 
-	fmt.Printf("BFS Multiple: Found %d unique paths (using %d/%d unique ingredient combinations) for '%s' (requested %d).\n",
+	tracef("BFS Multiple: Found %d unique paths (using %d/%d unique ingredient combinations) for '%s' (requested %d).\n",
 		foundCount, foundCombinations, uniqueRecipeCombos, targetElement, maxRecipes)
 	return result, int(nodesVisitedCount.Load()), nil
 }
 
-func getAllUniqueRecipeCombinations(element string) (int, map[string]Recipe) {
-	uniqueCombos := make(map[string]Recipe)
+// getAllUniqueRecipeCombinations mengembalikan satu ID resep per pasangan bahan unik yang menghasilkan element.
+func getAllUniqueRecipeCombinations(g *CompactGraph, element string) (int, map[string]int32) {
+	uniqueCombos := make(map[string]int32)
 
 	if isBaseElement(element) {
 		return 0, uniqueCombos
 	}
-
-	graph := GetAlchemyGraph()
-	if graph == nil {
+	id, exists := g.ID(element)
+	if !exists {
 		return 0, uniqueCombos
 	}
 
-	for _, recipes := range graph {
-		for _, recipe := range recipes {
-			if recipe.Result == element {
-				comboKey := getUniqueRecipeKey(recipe)
-				uniqueCombos[comboKey] = recipe
-			}
+	first, last := g.Producers(id)
+	for rid := first; rid < last; rid++ {
+		key := getUniqueRecipeKey(g.Recipe(rid))
+		if _, exists := uniqueCombos[key]; !exists {
+			uniqueCombos[key] = rid
 		}
 	}
 
 	return len(uniqueCombos), uniqueCombos
 }
 
-func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
-	strategyVariant int, nodesVisitedCount *atomic.Int32, shouldStop func() bool) []Recipe {
+// idDeque adalah antrean ID elemen yang juga bisa didahului (pushFront) untuk elemen prioritas.
+type idDeque struct {
+	front []int32 // tumpukan: elemen terakhir keluar lebih dulu
+	back  []int32
+	head  int
+}
 
-	ing1 := targetRecipe.Ingredient1
-	ing2 := targetRecipe.Ingredient2
+func (q *idDeque) len() int { return len(q.front) + len(q.back) - q.head }
 
-	queue := list.New()
-	localVisited := make(map[string]bool)
-	parent := make(map[string]Recipe)
-	discovered := make(map[string]bool)
-	depthMap := make(map[string]int)
+func (q *idDeque) pushFront(id int32) { q.front = append(q.front, id) }
 
-	for _, base := range baseElements {
-		queue.PushBack(base)
-		localVisited[base] = true
-		discovered[base] = true
-		depthMap[base] = 0
+func (q *idDeque) pushBack(id int32) { q.back = append(q.back, id) }
+
+func (q *idDeque) popFront() int32 {
+	if n := len(q.front); n > 0 {
+		id := q.front[n-1]
+		q.front = q.front[:n-1]
+		return id
 	}
+	id := q.back[q.head]
+	q.head++
+	return id
+}
 
-	for queue.Len() > 0 && !shouldStop() {
-		currentElement := queue.Remove(queue.Front()).(string)
-		currentDepth := depthMap[currentElement]
-		nodesVisitedCount.Add(1)
+// bfsWorker menyimpan keadaan BFS satu worker multiple dalam array berindeks ID elemen.
+type bfsWorker struct {
+	g           *CompactGraph
+	queue       idDeque
+	pairVisited map[uint64]bool
+	parent      []int32 // ID resep, -1 jika belum ada
+	depth       []int32
+	discovered  []bool
+	others      []int32
+}
 
-		if discovered[ing1] && discovered[ing2] {
-			if !discovered[targetElement] {
-				parent[targetElement] = targetRecipe
-				depthMap[targetElement] = max(depthMap[ing1], depthMap[ing2]) + 1
-				discovered[targetElement] = true
+// newBFSWorker memulai dari elemen dasar, dirotasi sebanyak startOffset supaya urutan antar worker berbeda.
+func newBFSWorker(g *CompactGraph, startOffset int) *bfsWorker {
+	n := g.NumElements()
+	w := &bfsWorker{
+		g:           g,
+		pairVisited: make(map[uint64]bool),
+		parent:      make([]int32, n),
+		depth:       make([]int32, n),
+		discovered:  make([]bool, n),
+	}
+	for i := range w.parent {
+		w.parent[i] = -1
+	}
+	for i := range baseElements {
+		base, _ := g.ID(baseElements[(startOffset+i)%len(baseElements)])
+		w.queue.pushBack(base)
+		w.discovered[base] = true
+	}
+	return w
+}
 
-				return buildDiversePath(parent, targetElement, strategyVariant)
-			}
+// combinable mengembalikan bahan yang sudah ditemukan dan bisa dipasangkan dengan current lewat resep,
+// untuk pasangan yang belum pernah dibuka, terurut menurut strategi. Hanya resep yang memakai current yang
+// diperiksa, bukan seluruh elemen yang sudah ditemukan.
+func (w *bfsWorker) combinable(current int32, strategyVariant, seed int) []int32 {
+	w.others = w.others[:0]
+	for _, rid := range w.g.Uses(current) {
+		other := w.g.Other(rid, current)
+		if !w.discovered[other] {
+			continue
 		}
-
-		combinableElements := make([]string, 0, len(discovered))
-		for elem := range discovered {
-			combinableElements = append(combinableElements, elem)
+		key := pairKey(current, other)
+		if w.pairVisited[key] {
+			continue
 		}
+		w.pairVisited[key] = true
+		w.others = append(w.others, other)
+	}
+	sortElements(w.g, w.others, strategyVariant, w.depth, seed)
+	return w.others
+}
 
-		sortElements(combinableElements, strategyVariant, depthMap, int(nodesVisitedCount.Load()))
+func findPathForSpecificCombination(g *CompactGraph, targetRecipe int32,
+	strategyVariant int, nodesVisitedCount *atomic.Int32, shouldStop func() bool) []Recipe {
 
-		for _, otherElement := range combinableElements {
-			pairKey := getPairKey(currentElement, otherElement)
+	target := g.recipes[targetRecipe].result
+	ing1 := g.recipes[targetRecipe].ingredient1
+	ing2 := g.recipes[targetRecipe].ingredient2
 
-			if localVisited[pairKey] {
-				continue
-			}
-			localVisited[pairKey] = true
+	worker := newBFSWorker(g, 0)
+
+	for worker.queue.len() > 0 && !shouldStop() {
+		currentElement := worker.queue.popFront()
+		currentDepth := worker.depth[currentElement]
+		nodesVisitedCount.Add(1)
+
+		if worker.discovered[ing1] && worker.discovered[ing2] && !worker.discovered[target] {
+			worker.parent[target] = targetRecipe
+			worker.depth[target] = max32(worker.depth[ing1], worker.depth[ing2]) + 1
+			worker.discovered[target] = true
 
-			recipes := getRecipes(currentElement, otherElement)
+			return buildDiversePath(g, worker.parent, target, strategyVariant)
+		}
 
-			for _, recipe := range recipes {
+		for _, otherElement := range worker.combinable(currentElement, strategyVariant, int(nodesVisitedCount.Load())) {
+			for _, rid := range g.PairRecipes(currentElement, otherElement) {
 				if shouldStop() {
 					return []Recipe{}
 				}
 
-				result := recipe.Result
+				result := g.recipes[rid].result
 				resultDepth := currentDepth + 1
 
-				_, alreadyFound := parent[result]
-
+				alreadyFound := worker.parent[result] >= 0
 				isPriorityElement := result == ing1 || result == ing2
 
 				if !alreadyFound || isPriorityElement {
-					parent[result] = recipe
-					depthMap[result] = resultDepth
+					worker.parent[result] = rid
+					worker.depth[result] = resultDepth
 				}
 
-				wasNewDiscovery := !discovered[result]
-				discovered[result] = true
-
-				if wasNewDiscovery || isPriorityElement {
-					if !localVisited[result] || isPriorityElement {
-						localVisited[result] = true
+				wasNewDiscovery := !worker.discovered[result]
+				worker.discovered[result] = true
 
-						if isPriorityElement {
-							queue.PushFront(result)
-						} else {
-							queue.PushBack(result)
-						}
-					}
+				if isPriorityElement {
+					worker.queue.pushFront(result)
+				} else if wasNewDiscovery {
+					worker.queue.pushBack(result)
 				}
 			}
 		}
@@ -789,41 +818,47 @@ func findPathForSpecificCombination(targetElement string, targetRecipe Recipe,
 	return []Recipe{}
 }
 
-func sortElements(elements []string, strategyVariant int, depthMap map[string]int, seed int) {
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// sortElements mengurutkan ID elemen menurut strategi worker. ID mengikuti urutan nama, jadi urutan
+// alfabet cukup membandingkan ID.
+func sortElements(g *CompactGraph, elements []int32, strategyVariant int, depth []int32, seed int) {
 	switch strategyVariant {
 	case 0:
 		// Alphabetical
-		sort.Strings(elements)
+		sort.Slice(elements, func(i, j int) bool { return elements[i] < elements[j] })
 	case 1:
 		// Reverse alphabetical
-		sort.Slice(elements, func(i, j int) bool {
-			return elements[i] > elements[j]
-		})
+		sort.Slice(elements, func(i, j int) bool { return elements[i] > elements[j] })
 	case 2:
 		// By depth (shallow first)
 		sort.Slice(elements, func(i, j int) bool {
-			depthI := depthMap[elements[i]]
-			depthJ := depthMap[elements[j]]
-			if depthI != depthJ {
-				return depthI < depthJ
+			if depth[elements[i]] != depth[elements[j]] {
+				return depth[elements[i]] < depth[elements[j]]
 			}
 			return elements[i] < elements[j]
 		})
 	case 3:
 		// By depth (deep first)
 		sort.Slice(elements, func(i, j int) bool {
-			depthI := depthMap[elements[i]]
-			depthJ := depthMap[elements[j]]
-			if depthI != depthJ {
-				return depthI > depthJ
+			if depth[elements[i]] != depth[elements[j]] {
+				return depth[elements[i]] > depth[elements[j]]
 			}
 			return elements[i] < elements[j]
 		})
 	case 4:
 		// Pseudo-random but deterministic ordering
+		hash := func(id int32) int {
+			name := g.Name(id)
+			return (seed*31 + len(name)*43 + int(name[0])) % 100
+		}
 		sort.Slice(elements, func(i, j int) bool {
-			hashI := (seed*31 + len(elements[i])*43 + int(elements[i][0])) % 100
-			hashJ := (seed*31 + len(elements[j])*43 + int(elements[j][0])) % 100
+			hashI, hashJ := hash(elements[i]), hash(elements[j])
 			if hashI != hashJ {
 				return hashI < hashJ
 			}
@@ -832,59 +867,51 @@ func sortElements(elements []string, strategyVariant int, depthMap map[string]in
 	}
 }
 
-func buildDiversePath(parent map[string]Recipe, target string, workerID int) []Recipe {
-	elementsNeeded := make(map[string]bool)
-	queue := list.New()
-	queue.PushBack(target)
-	processed := make(map[string]bool)
+// buildDiversePath menyusun langkah dari array parent (ID resep per elemen). Setiap langkah memilih satu
+// resep yang bahannya sudah tersedia, dengan urutan pilihan yang berbeda per worker.
+func buildDiversePath(g *CompactGraph, parent []int32, target int32, workerID int) []Recipe {
+	processed := make([]bool, g.NumElements())
 	processed[target] = true
-	elementsNeeded[target] = true
+	elementsNeeded := []int32{target}
 
-	for queue.Len() > 0 {
-		current := queue.Remove(queue.Front()).(string)
-
-		if isBaseElement(current) {
+	for i := 0; i < len(elementsNeeded); i++ {
+		current := elementsNeeded[i]
+		if g.IsBase(current) {
 			continue
 		}
 
-		recipe, exists := parent[current]
-		if !exists {
+		rid := parent[current]
+		if rid < 0 {
 			return []Recipe{}
 		}
 
-		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
-			if processed[ingredient] || isBaseElement(ingredient) {
+		for _, ingredient := range []int32{g.recipes[rid].ingredient1, g.recipes[rid].ingredient2} {
+			if processed[ingredient] || g.IsBase(ingredient) {
 				continue
 			}
-
 			processed[ingredient] = true
-			elementsNeeded[ingredient] = true
-			queue.PushBack(ingredient)
+			elementsNeeded = append(elementsNeeded, ingredient)
 		}
 	}
 
-	var result []Recipe
-	available := make(map[string]bool)
-
+	var result []int32
+	available := make([]bool, g.NumElements())
 	for _, base := range baseElements {
-		available[base] = true
+		if id, exists := g.ID(base); exists {
+			available[id] = true
+		}
 	}
 
+	strategyVariant := workerID % 3
 	for !available[target] {
-		candidates := make([]Recipe, 0)
-
-		for element := range elementsNeeded {
+		candidates := make([]int32, 0)
+		for _, element := range elementsNeeded {
 			if available[element] {
 				continue
 			}
-
-			recipe, exists := parent[element]
-			if !exists {
-				continue
-			}
-
-			if available[recipe.Ingredient1] && available[recipe.Ingredient2] {
-				candidates = append(candidates, recipe)
+			rid := parent[element]
+			if available[g.recipes[rid].ingredient1] && available[g.recipes[rid].ingredient2] {
+				candidates = append(candidates, rid)
 			}
 		}
 
@@ -892,34 +919,40 @@ func buildDiversePath(parent map[string]Recipe, target string, workerID int) []R
 			return []Recipe{}
 		}
 
-		strategyVariant := workerID % 3
 		sort.SliceStable(candidates, func(i, j int) bool {
+			ri, rj := g.recipes[candidates[i]], g.recipes[candidates[j]]
 			switch strategyVariant {
 			case 0:
-				return candidates[i].Result < candidates[j].Result
+				return ri.result < rj.result
 			case 1:
-				return candidates[i].Result > candidates[j].Result
+				return ri.result > rj.result
 			default:
-				ing1i := candidates[i].Ingredient1 + candidates[i].Ingredient2
-				ing1j := candidates[j].Ingredient1 + candidates[j].Ingredient2
-				return ing1i < ing1j
+				return g.Name(ri.ingredient1)+g.Name(ri.ingredient2) < g.Name(rj.ingredient1)+g.Name(rj.ingredient2)
 			}
 		})
 
-		recipe := candidates[0]
-		result = append(result, recipe)
-		available[recipe.Result] = true
-
-		if available[target] {
-			break
-		}
+		rid := candidates[0]
+		result = append(result, rid)
+		available[g.recipes[rid].result] = true
 	}
 
-	return result
+	return g.recipesToPath(result)
 }
 
 func ResetCaches() {
-	bfsPathCacheMutex.Lock()
-	bfsPathCache = make(map[string][]Recipe)
-	bfsPathCacheMutex.Unlock()
+	datasetMu.Lock()
+	sharedBFSPathCache = newBFSPathCache()
+	datasetMu.Unlock()
+}
+
+func getSharedBFSPathCache() *bfsPathCache {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
+	return sharedBFSPathCache
+}
+
+func getCompactGraphWithBFSCache() (*CompactGraph, *bfsPathCache) {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
+	return compactGraph, sharedBFSPathCache
 }
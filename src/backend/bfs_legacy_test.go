@@ -0,0 +1,116 @@
+// src/backend/bfs_legacy_test.go
+package main
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+func getPairKey(a, b string) string {
+	if a > b {
+		return b + ":" + a
+	}
+	return a + ":" + b
+}
+
+// legacyFindPathBFS adalah FindPathBFS sebelum memakai CompactGraph (map berbasis nama, kunci pasangan
+// string, dan daftar elemen yang diurutkan ulang setiap dequeue), tanpa cache dan log per node. Disimpan
+// untuk memastikan versi ID integer menghasilkan jalur yang sama dan untuk membandingkan benchmark.
+func legacyFindPathBFS(targetElement string) ([]Recipe, int, error) {
+	graph := GetAlchemyGraph()
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+
+	queue := list.New()
+	visited := make(map[string]bool, 1000)
+	recipeParent := make(map[string]Recipe)
+	discovered := make(map[string]bool, 1000)
+	depth := make(map[string]int)
+	nodesVisitedCount := 0
+
+	sortedBaseElements := append([]string(nil), baseElements...)
+	sort.Strings(sortedBaseElements)
+	for _, base := range sortedBaseElements {
+		discovered[base] = true
+		queue.PushBack(base)
+		depth[base] = 0
+	}
+
+	for queue.Len() > 0 {
+		currentElement := queue.Remove(queue.Front()).(string)
+		currentDepth := depth[currentElement]
+		nodesVisitedCount++
+
+		if len(graph[currentElement]) == 0 {
+			continue
+		}
+		discoveredElementsList := make([]string, 0, len(discovered))
+		for element := range discovered {
+			discoveredElementsList = append(discoveredElementsList, element)
+		}
+		sort.Strings(discoveredElementsList)
+
+		for _, otherElement := range discoveredElementsList {
+			pairKey := getPairKey(currentElement, otherElement)
+			if visited[pairKey] {
+				continue
+			}
+			visited[pairKey] = true
+
+			for _, recipe := range legacyGetRecipes(graph, currentElement, otherElement) {
+				result := recipe.Result
+				if discovered[result] {
+					continue
+				}
+				discovered[result] = true
+				recipeParent[result] = recipe
+				depth[result] = currentDepth + 1
+				if result == targetElement {
+					return buildRecipePath(recipeParent, targetElement, depth), nodesVisitedCount, nil
+				}
+				queue.PushBack(result)
+			}
+		}
+	}
+	return nil, nodesVisitedCount, fmt.Errorf("path to element '%s' not found", targetElement)
+}
+
+func legacyGetRecipes(graph map[string][]Recipe, a, b string) []Recipe {
+	var result []Recipe
+	sourceRecipes := graph[a]
+	if len(graph[b]) < len(sourceRecipes) {
+		sourceRecipes = graph[b]
+	}
+	for _, r := range sourceRecipes {
+		if (r.Ingredient1 == a && r.Ingredient2 == b) || (r.Ingredient1 == b && r.Ingredient2 == a) {
+			result = append(result, r)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Result < result[j].Result })
+	return result
+}
+
+func TestCompactBFSMatchesLegacyBFS(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	for _, element := range elements {
+		ResetCaches()
+		path, nodes, err := FindPathBFS(element)
+		legacyPath, legacyNodes, legacyErr := legacyFindPathBFS(element)
+		if (err == nil) != (legacyErr == nil) || nodes != legacyNodes || fmt.Sprint(path) != fmt.Sprint(legacyPath) {
+			t.Errorf("BFS %s berbeda dari versi lama: %d node %v, lama %d node %v", element, nodes, path, legacyNodes, legacyPath)
+		}
+	}
+}
+
+func BenchmarkLegacyFindPathBFS(b *testing.B) {
+	benchmarkShortest(b, legacyFindPathBFS)
+}
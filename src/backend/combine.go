@@ -0,0 +1,165 @@
+// src/backend/combine.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type CombinePair struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// CombineResult menjawab "apa hasil menggabungkan A dan B". Nama A dan B sudah dinormalisasi.
+type CombineResult struct {
+	A       string   `json:"a"`
+	B       string   `json:"b"`
+	Results []string `json:"results"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type CombineBatchRequest struct {
+	Pairs []CombinePair `json:"pairs"`
+}
+
+type CombineBatchResponse struct {
+	Results []CombineResult `json:"results"`
+}
+
+// RecipeBookEntry adalah kebalikan dari CombineResult: semua pasangan bahan yang menghasilkan Element.
+type RecipeBookEntry struct {
+	Element string        `json:"element"`
+	IsBase  bool          `json:"isBase"`
+	Pairs   []CombinePair `json:"pairs"`
+}
+
+// resolveExistingElement menormalisasi nama seperti parameter target pada /api/search.
+func resolveExistingElement(param, raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return "", fmt.Errorf("Parameter '%s' diperlukan", param)
+	}
+	name := resolveElementName(raw)
+	if !IsElementExists(name) {
+		return "", fmt.Errorf("Elemen '%s' tidak valid atau tidak ditemukan", strings.TrimSpace(raw))
+	}
+	return name, nil
+}
+
+// CombineElements mengembalikan semua hasil dari pasangan a dan b, terurut berdasarkan nama hasil.
+func CombineElements(a, b string) (CombineResult, error) {
+	nameA, err := resolveExistingElement("a", a)
+	if err != nil {
+		return CombineResult{A: a, B: b}, err
+	}
+	nameB, err := resolveExistingElement("b", b)
+	if err != nil {
+		return CombineResult{A: nameA, B: b}, err
+	}
+	result := CombineResult{A: nameA, B: nameB, Results: []string{}}
+	for _, recipe := range getRecipes(nameA, nameB) {
+		result.Results = append(result.Results, recipe.Result)
+	}
+	return result, nil
+}
+
+// CombinePairs menjalankan CombineElements untuk setiap pasangan; kesalahan dicatat per pasangan.
+func CombinePairs(pairs []CombinePair) CombineBatchResponse {
+	response := CombineBatchResponse{Results: make([]CombineResult, len(pairs))}
+	for i, pair := range pairs {
+		result, err := CombineElements(pair.A, pair.B)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		response.Results[i] = result
+	}
+	return response
+}
+
+// RecipesProducing mengembalikan semua pasangan bahan yang menghasilkan element. Bahan dalam satu pasangan
+// diurutkan berdasarkan nama, lalu pasangan diurutkan agar daftar resep stabil.
+func RecipesProducing(element string) (RecipeBookEntry, error) {
+	name, err := resolveExistingElement("result", element)
+	if err != nil {
+		return RecipeBookEntry{}, err
+	}
+	entry := RecipeBookEntry{Element: name, IsBase: isBaseElement(name), Pairs: []CombinePair{}}
+	g := GetCompactGraph()
+	if g == nil {
+		return entry, errors.New("data resep belum diinisialisasi")
+	}
+	id, exists := g.ID(name)
+	if !exists {
+		return entry, nil
+	}
+	first, last := g.Producers(id)
+	for rid := first; rid < last; rid++ {
+		recipe := g.Recipe(rid)
+		pair := CombinePair{A: recipe.Ingredient1, B: recipe.Ingredient2}
+		if pair.A > pair.B {
+			pair.A, pair.B = pair.B, pair.A
+		}
+		entry.Pairs = append(entry.Pairs, pair)
+	}
+	sort.Slice(entry.Pairs, func(i, j int) bool {
+		if entry.Pairs[i].A != entry.Pairs[j].A {
+			return entry.Pairs[i].A < entry.Pairs[j].A
+		}
+		return entry.Pairs[i].B < entry.Pairs[j].B
+	})
+	return entry, nil
+}
+
+// combineHandler melayani /api/combine:
+//   - GET ?a=X&b=Y: hasil dari pasangan X dan Y
+//   - GET ?result=Z: semua pasangan yang menghasilkan Z (buku resep)
+//   - POST {"pairs": [{"a": ..., "b": ...}]}: banyak pasangan sekaligus
+func combineHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		query := r.URL.Query()
+		if query.Has("result") {
+			entry, err := RecipesProducing(query.Get("result"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeJSON(w, entry)
+			return
+		}
+		result, err := CombineElements(query.Get("a"), query.Get("b"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, result)
+	case http.MethodPost:
+		var request CombineBatchRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+			return
+		}
+		if len(request.Pairs) == 0 {
+			http.Error(w, "Field 'pairs' tidak boleh kosong", http.StatusBadRequest)
+			return
+		}
+		if len(request.Pairs) > maxBatchItems {
+			http.Error(w, fmt.Sprintf("Jumlah pasangan maksimal %d", maxBatchItems), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, CombinePairs(request.Pairs))
+	default:
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,78 @@
+// src/backend/combine_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCombineElementsNormalisesNames(t *testing.T) {
+	loadTestDataset(t)
+
+	result, err := CombineElements("water", " FIRE ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.A != "Water" || result.B != "Fire" || !reflect.DeepEqual(result.Results, []string{"Steam"}) {
+		t.Errorf("hasil Water + Fire salah: %+v", result)
+	}
+
+	reversed, err := CombineElements("Fire", "Water")
+	if err != nil || !reflect.DeepEqual(reversed.Results, result.Results) {
+		t.Errorf("urutan bahan seharusnya tidak berpengaruh: %+v %v", reversed, err)
+	}
+
+	if _, err := CombineElements("Water", "Bukan elemen"); err == nil {
+		t.Error("elemen yang tidak ada seharusnya ditolak")
+	}
+	if _, err := CombineElements("", "Water"); err == nil {
+		t.Error("parameter kosong seharusnya ditolak")
+	}
+}
+
+func TestCombinePairsReportsErrorsPerPair(t *testing.T) {
+	loadTestDataset(t)
+
+	response := CombinePairs([]CombinePair{{A: "Water", B: "Earth"}, {A: "Water", B: "Bukan elemen"}, {A: "Air", B: "Air"}})
+	if len(response.Results) != 3 {
+		t.Fatalf("jumlah hasil %d", len(response.Results))
+	}
+	if !reflect.DeepEqual(response.Results[0].Results, []string{"Mud"}) || response.Results[0].Error != "" {
+		t.Errorf("Water + Earth seharusnya Mud: %+v", response.Results[0])
+	}
+	if response.Results[1].Error == "" {
+		t.Errorf("pasangan dengan elemen tidak dikenal seharusnya berisi error: %+v", response.Results[1])
+	}
+}
+
+func TestRecipesProducingMatchesCombine(t *testing.T) {
+	loadTestDataset(t)
+
+	for _, element := range benchmarkTargets {
+		entry, err := RecipesProducing(element)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entry.Pairs) != len(GetRecipeMap()[element]) {
+			t.Errorf("%s: %d pasangan, %d resep", element, len(entry.Pairs), len(GetRecipeMap()[element]))
+		}
+		for _, pair := range entry.Pairs {
+			result, err := CombineElements(pair.A, pair.B)
+			if err != nil {
+				t.Fatal(err)
+			}
+			found := false
+			for _, name := range result.Results {
+				found = found || name == element
+			}
+			if !found {
+				t.Errorf("%s + %s seharusnya menghasilkan %s: %v", pair.A, pair.B, element, result.Results)
+			}
+		}
+	}
+
+	base, err := RecipesProducing("fire")
+	if err != nil || !base.IsBase || base.Element != "Fire" {
+		t.Errorf("elemen dasar salah: %+v %v", base, err)
+	}
+}
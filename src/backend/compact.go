@@ -0,0 +1,240 @@
+// src/backend/compact.go
+package main
+
+import "sort"
+
+// CompactGraph adalah dataset yang sudah di-intern menjadi ID integer padat. ID elemen mengikuti urutan
+// nama, sehingga mengurutkan ID sama dengan mengurutkan nama. Resep untuk hasil yang sama menempati ID
+// berurutan (urutan file dipertahankan), jadi "resep yang menghasilkan X" cukup disimpan sebagai offset CSR.
+type CompactGraph struct {
+	names []string
+	ids   map[string]int32
+	base  []bool
+
+	recipes []compactRecipe
+	// rank adalah posisi resep jika diurutkan berdasarkan getRecipeID, untuk tie-break deterministik.
+	rank []int32
+
+	// Resep yang menghasilkan elemen e adalah ID producesOffsets[e] sampai producesOffsets[e+1]-1.
+	producesOffsets []int32
+	// producersByRank[producesOffsets[e]:producesOffsets[e+1]] adalah resep yang sama, terurut berdasarkan rank.
+	producersByRank []int32
+	// uses[usesOffsets[e]:usesOffsets[e+1]] adalah resep yang memakai e sebagai bahan, terurut berdasarkan
+	// bahan lainnya lalu hasilnya (urutan yang sama dengan BFS berbasis nama).
+	usesOffsets []int32
+	uses        []int32
+	// pairs memetakan pasangan bahan (a,b) ke resep yang dihasilkannya, terurut berdasarkan hasil.
+	pairs map[uint64][]int32
+}
+
+type compactRecipe struct {
+	result, ingredient1, ingredient2 int32
+}
+
+func pairKey(a, b int32) uint64 {
+	if a > b {
+		a, b = b, a
+	}
+	return uint64(uint32(a))<<32 | uint64(uint32(b))
+}
+
+func buildCompactGraph(inputRecipeMap map[string][]Recipe) *CompactGraph {
+	nameSet := make(map[string]bool)
+	for _, base := range baseElements {
+		nameSet[base] = true
+	}
+	for result, recipes := range inputRecipeMap {
+		nameSet[result] = true
+		for _, recipe := range recipes {
+			nameSet[recipe.Ingredient1] = true
+			nameSet[recipe.Ingredient2] = true
+		}
+	}
+	g := &CompactGraph{ids: make(map[string]int32, len(nameSet))}
+	for name := range nameSet {
+		g.names = append(g.names, name)
+	}
+	sort.Strings(g.names)
+	g.base = make([]bool, len(g.names))
+	for id, name := range g.names {
+		g.ids[name] = int32(id)
+		g.base[id] = isBaseElement(name)
+	}
+
+	g.producesOffsets = make([]int32, len(g.names)+1)
+	for id, name := range g.names {
+		g.producesOffsets[id] = int32(len(g.recipes))
+		for _, recipe := range inputRecipeMap[name] {
+			g.recipes = append(g.recipes, compactRecipe{
+				result:      int32(id),
+				ingredient1: g.ids[recipe.Ingredient1],
+				ingredient2: g.ids[recipe.Ingredient2],
+			})
+		}
+	}
+	g.producesOffsets[len(g.names)] = int32(len(g.recipes))
+
+	order := make([]int32, len(g.recipes))
+	recipeIDs := make([]string, len(g.recipes))
+	for rid := range g.recipes {
+		order[rid] = int32(rid)
+		recipeIDs[rid] = getRecipeID(g.Recipe(int32(rid)))
+	}
+	sort.Slice(order, func(i, j int) bool { return recipeIDs[order[i]] < recipeIDs[order[j]] })
+	g.rank = make([]int32, len(g.recipes))
+	for position, rid := range order {
+		g.rank[rid] = int32(position)
+	}
+	g.producersByRank = make([]int32, len(g.recipes))
+	for rid := range g.producersByRank {
+		g.producersByRank[rid] = int32(rid)
+	}
+	for id := range g.names {
+		list := g.producersByRank[g.producesOffsets[id]:g.producesOffsets[id+1]]
+		sort.Slice(list, func(i, j int) bool { return g.rank[list[i]] < g.rank[list[j]] })
+	}
+
+	// CSR "resep yang memakai X": hitung dulu jumlah per elemen, lalu isi dan urutkan per elemen.
+	g.usesOffsets = make([]int32, len(g.names)+1)
+	for _, recipe := range g.recipes {
+		g.usesOffsets[recipe.ingredient1+1]++
+		if recipe.ingredient2 != recipe.ingredient1 {
+			g.usesOffsets[recipe.ingredient2+1]++
+		}
+	}
+	for id := 1; id <= len(g.names); id++ {
+		g.usesOffsets[id] += g.usesOffsets[id-1]
+	}
+	g.uses = make([]int32, g.usesOffsets[len(g.names)])
+	fill := append([]int32(nil), g.usesOffsets[:len(g.names)]...)
+	for rid, recipe := range g.recipes {
+		g.uses[fill[recipe.ingredient1]] = int32(rid)
+		fill[recipe.ingredient1]++
+		if recipe.ingredient2 != recipe.ingredient1 {
+			g.uses[fill[recipe.ingredient2]] = int32(rid)
+			fill[recipe.ingredient2]++
+		}
+	}
+	for id := range g.names {
+		element := int32(id)
+		list := g.Uses(element)
+		sort.Slice(list, func(i, j int) bool {
+			otherI, otherJ := g.Other(list[i], element), g.Other(list[j], element)
+			if otherI != otherJ {
+				return otherI < otherJ
+			}
+			if g.recipes[list[i]].result != g.recipes[list[j]].result {
+				return g.recipes[list[i]].result < g.recipes[list[j]].result
+			}
+			return list[i] < list[j]
+		})
+	}
+
+	// Indeks pasangan memakai satu array bersama; setiap entri map adalah potongan dari array itu.
+	byPair := make([]int32, len(g.recipes))
+	for rid := range byPair {
+		byPair[rid] = int32(rid)
+	}
+	sort.Slice(byPair, func(i, j int) bool {
+		keyI := pairKey(g.recipes[byPair[i]].ingredient1, g.recipes[byPair[i]].ingredient2)
+		keyJ := pairKey(g.recipes[byPair[j]].ingredient1, g.recipes[byPair[j]].ingredient2)
+		if keyI != keyJ {
+			return keyI < keyJ
+		}
+		if g.recipes[byPair[i]].result != g.recipes[byPair[j]].result {
+			return g.recipes[byPair[i]].result < g.recipes[byPair[j]].result
+		}
+		return byPair[i] < byPair[j]
+	})
+	g.pairs = make(map[uint64][]int32)
+	for start := 0; start < len(byPair); {
+		key := pairKey(g.recipes[byPair[start]].ingredient1, g.recipes[byPair[start]].ingredient2)
+		end := start + 1
+		for end < len(byPair) && pairKey(g.recipes[byPair[end]].ingredient1, g.recipes[byPair[end]].ingredient2) == key {
+			end++
+		}
+		g.pairs[key] = byPair[start:end:end]
+		start = end
+	}
+
+	tracef("Graf ringkas selesai dibangun: %d elemen, %d resep, %d pasangan bahan.\n", len(g.names), len(g.recipes), len(g.pairs))
+	return g
+}
+
+func (g *CompactGraph) NumElements() int { return len(g.names) }
+
+func (g *CompactGraph) ID(name string) (int32, bool) {
+	id, exists := g.ids[name]
+	return id, exists
+}
+
+func (g *CompactGraph) Name(id int32) string { return g.names[id] }
+
+func (g *CompactGraph) IsBase(id int32) bool { return g.base[id] }
+
+func (g *CompactGraph) Recipe(rid int32) Recipe {
+	recipe := g.recipes[rid]
+	return Recipe{Result: g.names[recipe.result], Ingredient1: g.names[recipe.ingredient1], Ingredient2: g.names[recipe.ingredient2]}
+}
+
+// Other mengembalikan bahan resep rid selain element (element sendiri jika kedua bahannya sama).
+func (g *CompactGraph) Other(rid, element int32) int32 {
+	if g.recipes[rid].ingredient1 == element {
+		return g.recipes[rid].ingredient2
+	}
+	return g.recipes[rid].ingredient1
+}
+
+// Producers mengembalikan rentang ID resep yang menghasilkan element, dalam urutan file.
+func (g *CompactGraph) Producers(element int32) (int32, int32) {
+	return g.producesOffsets[element], g.producesOffsets[element+1]
+}
+
+// ProducersByRank mengembalikan resep yang menghasilkan element terurut berdasarkan getRecipeID. Slice tidak
+// boleh diubah pemanggil.
+func (g *CompactGraph) ProducersByRank(element int32) []int32 {
+	return g.producersByRank[g.producesOffsets[element]:g.producesOffsets[element+1]]
+}
+
+// Uses mengembalikan resep yang memakai element sebagai bahan. Slice tidak boleh diubah pemanggil.
+func (g *CompactGraph) Uses(element int32) []int32 {
+	return g.uses[g.usesOffsets[element]:g.usesOffsets[element+1]]
+}
+
+// PairRecipes mengembalikan resep dengan pasangan bahan (a,b) terurut berdasarkan hasil.
+func (g *CompactGraph) PairRecipes(a, b int32) []int32 {
+	return g.pairs[pairKey(a, b)]
+}
+
+// recipesToPath mengubah daftar ID resep menjadi langkah resep.
+func (g *CompactGraph) recipesToPath(rids []int32) []Recipe {
+	path := make([]Recipe, len(rids))
+	for i, rid := range rids {
+		path[i] = g.Recipe(rid)
+	}
+	return path
+}
+
+// recipePathFromParents membangun langkah resep dari array parent dan kedalaman berbasis ID dengan
+// buildRecipePath, hanya untuk elemen yang dibutuhkan target.
+func (g *CompactGraph) recipePathFromParents(parent []int32, depth []int32, target int32) []Recipe {
+	recipeParent := make(map[string]Recipe)
+	depthByName := make(map[string]int)
+	stack := []int32{target}
+	for len(stack) > 0 {
+		element := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		name := g.names[element]
+		if _, seen := depthByName[name]; seen {
+			continue
+		}
+		depthByName[name] = int(depth[element])
+		if g.base[element] || parent[element] < 0 {
+			continue
+		}
+		recipe := g.recipes[parent[element]]
+		recipeParent[name] = g.Recipe(parent[element])
+		stack = append(stack, recipe.ingredient1, recipe.ingredient2)
+	}
+	return buildRecipePath(recipeParent, g.names[target], depthByName)
+}
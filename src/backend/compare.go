@@ -0,0 +1,180 @@
+// src/backend/compare.go
+package main
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type AlgorithmComparison struct {
+	Algorithm       string `json:"algorithm"`
+	PathFound       bool   `json:"pathFound"`
+	PathsReturned   int    `json:"pathsReturned"`
+	PathLength      int    `json:"pathLength"`
+	DistinctRecipes int    `json:"distinctRecipes"`
+	NodesVisited    int    `json:"nodesVisited"`
+	DurationMillis  int64  `json:"durationMillis"`
+	PlanIdentifier  string `json:"-"`
+	Error           string `json:"error,omitempty"`
+}
+
+type ComparisonSummary struct {
+	Algorithms       []AlgorithmComparison `json:"algorithms"`
+	ShortestPath     []string              `json:"shortestPath,omitempty"`
+	FewestNodes      []string              `json:"fewestNodes,omitempty"`
+	Fastest          []string              `json:"fastest,omitempty"`
+	AllEquivalent    bool                  `json:"allEquivalent"`
+	EquivalentGroups [][]string            `json:"equivalentGroups"`
+}
+
+type CompareResponse struct {
+	SearchTarget string                `json:"searchTarget"`
+	Mode         string                `json:"mode"`
+	MaxRecipes   int                   `json:"maxRecipes,omitempty"`
+	Results      []MultiSearchResponse `json:"results"`
+	Summary      ComparisonSummary     `json:"summary"`
+}
+
+func compareHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	targetElement := resolveElementName(r.URL.Query().Get("target"))
+	_, mode := normalizeSearchParams("", r.URL.Query().Get("mode"))
+	if err := validateSearchParams(targetElement, "bfs", mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxRecipes := 1
+	if mode == "multiple" {
+		maxRecipesStr := r.URL.Query().Get("max")
+		if maxRecipesStr == "" {
+			http.Error(w, "Parameter 'max' diperlukan untuk mode 'multiple'", http.StatusBadRequest)
+			return
+		}
+		var convErr error
+		maxRecipes, convErr = strconv.Atoi(maxRecipesStr)
+		if convErr != nil || maxRecipes <= 0 {
+			http.Error(w, "Parameter 'max' harus berupa angka positif lebih besar dari 0 untuk mode 'multiple'", http.StatusBadRequest)
+			return
+		}
+		maxRecipes = limitMaxRecipes(targetElement, maxRecipes)
+	}
+
+	var cost int64
+	for _, searcher := range searchersForMode(mode) {
+		cost += searcher.EstimateCost(mode, maxRecipes)
+	}
+	release, ok := acquireSearchSlot(w, r, cost)
+	if !ok {
+		return
+	}
+	defer release()
+
+	writeJSON(w, runComparison(targetElement, mode, maxRecipes))
+}
+
+// runComparison menjalankan semua algoritma terdaftar yang mendukung mode tersebut secara berurutan
+// agar waktu yang diukur tidak saling mengganggu.
+func runComparison(targetElement, mode string, maxRecipes int) CompareResponse {
+	log.Printf("Memulai perbandingan algoritma: Target=%s, Mode=%s, MaxRecipes=%d\n", targetElement, mode, maxRecipes)
+
+	// Perbandingan memakai cache BFS sendiri supaya NodesVisited BFS tidak bernilai 0 karena cache,
+	// tanpa mengosongkan cache bersama yang dipakai /api/search.
+	options := SearchOptions{PathCache: newBFSPathCache()}
+
+	response := CompareResponse{SearchTarget: targetElement, Mode: mode}
+	if mode == "multiple" {
+		response.MaxRecipes = maxRecipes
+	}
+	for _, searcher := range searchersForMode(mode) {
+		response.Results = append(response.Results, runSearchWithOptions(targetElement, searcher.Name(), mode, maxRecipes, 0, options))
+	}
+	response.Summary = summarizeComparison(response.Results)
+	return response
+}
+
+func summarizeComparison(results []MultiSearchResponse) ComparisonSummary {
+	summary := ComparisonSummary{EquivalentGroups: [][]string{}}
+	groupIndex := make(map[string]int)
+
+	for _, result := range results {
+		paths := result.Paths
+		if result.Mode == "shortest" {
+			paths = [][]Recipe{result.Path}
+		}
+
+		comparison := AlgorithmComparison{
+			Algorithm:      result.Algorithm,
+			PathFound:      result.PathFound,
+			NodesVisited:   result.NodesVisited,
+			DurationMillis: result.DurationMillis,
+			Error:          result.Error,
+		}
+		if result.PathFound {
+			comparison.PathsReturned = len(paths)
+			comparison.PathLength = -1
+			distinct := make(map[string]bool)
+			identifiers := make([]string, 0, len(paths))
+			for _, path := range paths {
+				if comparison.PathLength == -1 || len(path) < comparison.PathLength {
+					comparison.PathLength = len(path)
+				}
+				for _, recipe := range path {
+					distinct[getUniqueRecipeKey(recipe)] = true
+				}
+				identifiers = append(identifiers, generatePathIdentifier(path))
+			}
+			if comparison.PathLength == -1 {
+				comparison.PathLength = 0
+			}
+			comparison.DistinctRecipes = len(distinct)
+
+			// Pada mode multiple, rencana dianggap setara jika himpunan jalurnya sama.
+			sort.Strings(identifiers)
+			comparison.PlanIdentifier = strings.Join(identifiers, " || ")
+
+			if idx, exists := groupIndex[comparison.PlanIdentifier]; exists {
+				summary.EquivalentGroups[idx] = append(summary.EquivalentGroups[idx], result.Algorithm)
+			} else {
+				groupIndex[comparison.PlanIdentifier] = len(summary.EquivalentGroups)
+				summary.EquivalentGroups = append(summary.EquivalentGroups, []string{result.Algorithm})
+			}
+		}
+		summary.Algorithms = append(summary.Algorithms, comparison)
+	}
+
+	summary.AllEquivalent = len(summary.EquivalentGroups) == 1 && len(summary.EquivalentGroups[0]) == len(results)
+	summary.ShortestPath = bestAlgorithms(summary.Algorithms, func(c AlgorithmComparison) int64 { return int64(c.PathLength) })
+	summary.FewestNodes = bestAlgorithms(summary.Algorithms, func(c AlgorithmComparison) int64 { return int64(c.NodesVisited) })
+	summary.Fastest = bestAlgorithms(summary.Algorithms, func(c AlgorithmComparison) int64 { return c.DurationMillis })
+	return summary
+}
+
+// bestAlgorithms mengembalikan algoritma (bisa lebih dari satu jika seri) dengan nilai metrik terkecil.
+func bestAlgorithms(comparisons []AlgorithmComparison, metric func(AlgorithmComparison) int64) []string {
+	var best []string
+	var bestValue int64
+	for _, c := range comparisons {
+		if !c.PathFound {
+			continue
+		}
+		value := metric(c)
+		if best == nil || value < bestValue {
+			best = []string{c.Algorithm}
+			bestValue = value
+		} else if value == bestValue {
+			best = append(best, c.Algorithm)
+		}
+	}
+	return best
+}
@@ -0,0 +1,363 @@
+// src/backend/completion.go
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompletionStep adalah satu kombinasi dalam rute penyelesaian. Discovered berisi elemen yang baru
+// ditemukan oleh kombinasi itu; satu pasangan bahan bisa menghasilkan lebih dari satu elemen.
+type CompletionStep struct {
+	Step          int      `json:"step"`
+	A             string   `json:"a"`
+	B             string   `json:"b"`
+	Discovered    []string `json:"discovered"`
+	Tier          int      `json:"tier"`
+	InventorySize int      `json:"inventorySize"`
+}
+
+type CompletionRoute struct {
+	DatasetVersion   string           `json:"datasetVersion"`
+	Inventory        []string         `json:"inventory"`
+	Steps            []CompletionStep `json:"steps"`
+	Combinations     int              `json:"combinations"`
+	Discovered       int              `json:"discovered"`
+	MultiResultSteps int              `json:"multiResultSteps"`
+	Unreachable      []string         `json:"unreachable"`
+}
+
+type CompletionRequest struct {
+	Inventory []string `json:"inventory"`
+}
+
+var completionCSVHeader = []string{"step", "a", "b", "discovered", "tier", "inventory_size"}
+
+type completionPair struct {
+	a, b    string
+	results []string
+}
+
+// groupRecipesByPair mengelompokkan resep berdasarkan pasangan bahan (a <= b), terurut berdasarkan pasangan.
+func groupRecipesByPair(recipes []Recipe) []completionPair {
+	index := make(map[[2]string]int)
+	var pairs []completionPair
+	for _, recipe := range recipes {
+		a, b := recipe.Ingredient1, recipe.Ingredient2
+		if a > b {
+			a, b = b, a
+		}
+		key := [2]string{a, b}
+		i, exists := index[key]
+		if !exists {
+			i = len(pairs)
+			index[key] = i
+			pairs = append(pairs, completionPair{a: a, b: b})
+		}
+		pairs[i].results = append(pairs[i].results, recipe.Result)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].a != pairs[j].a {
+			return pairs[i].a < pairs[j].a
+		}
+		return pairs[i].b < pairs[j].b
+	})
+	for i := range pairs {
+		sort.Strings(pairs[i].results)
+	}
+	return pairs
+}
+
+// simulateCompletionRoute menjalankan urutan pasangan dari inventaris awal. Rute tidak valid jika ada pasangan
+// yang bahannya belum ditemukan; discovered berisi elemen baru per langkah.
+func simulateCompletionRoute(pairs []completionPair, route []int, start map[string]bool) (bool, map[string]bool, [][]string) {
+	known := make(map[string]bool, len(start)+len(route))
+	for element := range start {
+		known[element] = true
+	}
+	discovered := make([][]string, len(route))
+	for i, p := range route {
+		pair := pairs[p]
+		if !known[pair.a] || !known[pair.b] {
+			return false, known, nil
+		}
+		for _, result := range pair.results {
+			if !known[result] {
+				known[result] = true
+				discovered[i] = append(discovered[i], result)
+			}
+		}
+	}
+	return true, known, discovered
+}
+
+// resolveInventory menormalisasi inventaris pemain; elemen dasar selalu dianggap sudah dimiliki.
+func resolveInventory(raw []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var inventory []string
+	for _, name := range append(append([]string(nil), baseElements...), raw...) {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		resolved, err := resolveExistingElement("inventory", name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[resolved] {
+			seen[resolved] = true
+			inventory = append(inventory, resolved)
+		}
+	}
+	sort.Strings(inventory)
+	return inventory, nil
+}
+
+// splitInventoryParam memecah inventaris yang ditulis sebagai daftar nama dipisah koma.
+func splitInventoryParam(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
+
+// PlanCompletionRoute menyusun urutan kombinasi untuk menemukan semua elemen yang bisa dicapai dari inventaris.
+// Elemen yang bisa dicapai dihitung dengan propagasi yang sama seperti filter ketercapaian. Rute dibangun
+// secara greedy: pada setiap langkah dipilih pasangan yang bahannya sudah ditemukan dengan tier elemen baru
+// terendah, lalu yang menghasilkan elemen baru terbanyak. Setelah itu setiap langkah dicoba dihapus dari
+// belakang; langkah dibuang jika elemennya tetap ditemukan oleh kombinasi lain di rute, sehingga jumlah
+// kombinasi berkurang tanpa mengubah urutan tier.
+func PlanCompletionRoute(rawInventory []string) (CompletionRoute, error) {
+	recipesByResult := GetRecipeMap()
+	if recipesByResult == nil {
+		return CompletionRoute{}, errors.New("map resep belum diinisialisasi")
+	}
+	inventory, err := resolveInventory(rawInventory)
+	if err != nil {
+		return CompletionRoute{}, err
+	}
+
+	recipes := flattenRecipeMap(recipesByResult)
+	start := make(map[string]bool, len(inventory))
+	for _, element := range inventory {
+		start[element] = true
+	}
+	reachable := make(map[string]bool, len(GetAllElementNames()))
+	for element := range start {
+		reachable[element] = true
+	}
+	propagateMakeableElements(recipes, reachable)
+	tiers, _ := calculateElementTiers(recipes, inventory)
+
+	route := CompletionRoute{DatasetVersion: GetDatasetVersion(), Inventory: inventory, Steps: []CompletionStep{}, Unreachable: []string{}}
+	for element := range GetAllElementNames() {
+		if !reachable[element] {
+			route.Unreachable = append(route.Unreachable, element)
+		}
+	}
+	sort.Strings(route.Unreachable)
+
+	pairs := groupRecipesByPair(recipes)
+	known := make(map[string]bool, len(reachable))
+	for element := range start {
+		known[element] = true
+	}
+	var order []int
+	for len(known) < len(reachable) {
+		best, bestTier, bestNew := -1, 0, 0
+		for i, pair := range pairs {
+			if !known[pair.a] || !known[pair.b] {
+				continue
+			}
+			newCount, minTier := 0, 0
+			for _, result := range pair.results {
+				if known[result] {
+					continue
+				}
+				if newCount == 0 || tiers[result] < minTier {
+					minTier = tiers[result]
+				}
+				newCount++
+			}
+			if newCount == 0 {
+				continue
+			}
+			if best < 0 || minTier < bestTier || (minTier == bestTier && newCount > bestNew) {
+				best, bestTier, bestNew = i, minTier, newCount
+			}
+		}
+		if best < 0 {
+			return route, fmt.Errorf("rute penyelesaian berhenti dengan %d dari %d elemen", len(known), len(reachable))
+		}
+		order = append(order, best)
+		for _, result := range pairs[best].results {
+			known[result] = true
+		}
+	}
+
+	for i := len(order) - 1; i >= 0; i-- {
+		candidate := append(append([]int(nil), order[:i]...), order[i+1:]...)
+		if valid, reached, _ := simulateCompletionRoute(pairs, candidate, start); valid && len(reached) == len(reachable) {
+			order = candidate
+		}
+	}
+
+	_, _, discovered := simulateCompletionRoute(pairs, order, start)
+	inventorySize := len(start)
+	for i, p := range order {
+		minTier := -1
+		for _, element := range discovered[i] {
+			if minTier < 0 || tiers[element] < minTier {
+				minTier = tiers[element]
+			}
+		}
+		inventorySize += len(discovered[i])
+		route.Steps = append(route.Steps, CompletionStep{
+			Step:          i + 1,
+			A:             pairs[p].a,
+			B:             pairs[p].b,
+			Discovered:    discovered[i],
+			Tier:          minTier,
+			InventorySize: inventorySize,
+		})
+		if len(discovered[i]) > 1 {
+			route.MultiResultSteps++
+		}
+	}
+	route.Combinations = len(route.Steps)
+	route.Discovered = inventorySize - len(start)
+	return route, nil
+}
+
+func normalizeCompletionFormat(raw string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return "", errors.New("Parameter 'format' harus 'json' atau 'csv'")
+	}
+	return format, nil
+}
+
+func writeCompletionCSV(w io.Writer, route CompletionRoute) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(completionCSVHeader); err != nil {
+		return err
+	}
+	for _, step := range route.Steps {
+		row := []string{
+			strconv.Itoa(step.Step),
+			step.A,
+			step.B,
+			strings.Join(step.Discovered, ";"),
+			strconv.Itoa(step.Tier),
+			strconv.Itoa(step.InventorySize),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// completionHandler melayani /api/completion. Inventaris awal diberikan lewat ?inventory=A,B atau body POST
+// {"inventory": [...]}, atau diambil dari sesi permainan lewat ?session=<id>; tanpa inventaris rute dimulai
+// dari elemen dasar. ?format=csv mengunduh rute sebagai CSV.
+func completionHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+	var inventory []string
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet:
+		inventory = splitInventoryParam(r.URL.Query().Get("inventory"))
+	case http.MethodPost:
+		var request CompletionRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+			return
+		}
+		inventory = request.Inventory
+	default:
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id := r.URL.Query().Get("session"); id != "" {
+		sessionItems, err := sessionInventory(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		inventory = sessionItems
+	}
+	format, err := normalizeCompletionFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	route, err := PlanCompletionRoute(inventory)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=\"completion.csv\"")
+		if err := writeCompletionCSV(w, route); err != nil {
+			log.Printf("Error saat menulis rute penyelesaian: %v", err)
+		}
+		return
+	}
+	writeJSON(w, route)
+}
+
+// runCompletionCommand dipakai oleh flag -completion. Format diambil dari ekstensi file (json atau csv).
+func runCompletionCommand(dataDir, outPath, rawInventory string) error {
+	format, err := normalizeCompletionFormat(strings.TrimPrefix(strings.ToLower(filepath.Ext(outPath)), "."))
+	if err != nil {
+		return err
+	}
+	if err := InitData(dataDir); err != nil {
+		return err
+	}
+
+	route, err := PlanCompletionRoute(splitInventoryParam(rawInventory))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if format == "csv" {
+		err = writeCompletionCSV(file, route)
+	} else {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(route)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Rute penyelesaian (%d kombinasi, %d elemen baru, %d tidak tercapai) ditulis ke %s\n", route.Combinations, route.Discovered, len(route.Unreachable), outPath)
+	return file.Close()
+}
@@ -0,0 +1,83 @@
+// src/backend/completion_test.go
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestCompletionRouteDiscoversEveryReachableElement(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	route, err := PlanCompletionRoute(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]bool)
+	for _, element := range route.Inventory {
+		known[element] = true
+	}
+	for _, step := range route.Steps {
+		if !known[step.A] || !known[step.B] {
+			t.Fatalf("langkah %d memakai bahan yang belum ditemukan: %s + %s", step.Step, step.A, step.B)
+		}
+		if len(step.Discovered) == 0 {
+			t.Fatalf("langkah %d tidak menemukan elemen baru", step.Step)
+		}
+		results, err := CombineElements(step.A, step.B)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, element := range results.Results {
+			known[element] = true
+		}
+		if len(known) != step.InventorySize {
+			t.Fatalf("langkah %d: inventaris %d, seharusnya %d", step.Step, step.InventorySize, len(known))
+		}
+	}
+	if len(known)+len(route.Unreachable) != len(GetAllElementNames()) {
+		t.Errorf("%d elemen ditemukan dan %d tidak tercapai dari %d elemen", len(known), len(route.Unreachable), len(GetAllElementNames()))
+	}
+	if route.Combinations != len(route.Steps) || route.Discovered != len(known)-len(route.Inventory) {
+		t.Errorf("ringkasan rute tidak cocok: %+v", route)
+	}
+
+	var buffer bytes.Buffer
+	if err := writeCompletionCSV(&buffer, route); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	if err != nil || len(rows) != len(route.Steps)+1 {
+		t.Errorf("CSV memiliki %d baris, seharusnya %d: %v", len(rows), len(route.Steps)+1, err)
+	}
+}
+
+func TestCompletionRouteStartsFromInventory(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	full, err := PlanCompletionRoute(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, err := PlanCompletionRoute([]string{"mud", " Steam"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(route.Inventory) != len(baseElements)+2 || route.Discovered != full.Discovered-2 {
+		t.Errorf("inventaris %v menemukan %d elemen, rute penuh %d", route.Inventory, route.Discovered, full.Discovered)
+	}
+	for _, step := range route.Steps {
+		for _, element := range step.Discovered {
+			if element == "Mud" || element == "Steam" {
+				t.Errorf("langkah %d menemukan ulang %s", step.Step, element)
+			}
+		}
+	}
+
+	if _, err := PlanCompletionRoute([]string{"Bukan elemen"}); err == nil {
+		t.Error("inventaris dengan elemen tidak dikenal seharusnya ditolak")
+	}
+}
@@ -0,0 +1,308 @@
+// src/backend/count.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// Jumlah pohon tumbuh dua kali lipat eksponensial terhadap kedalaman, jadi nilai dibatasi (saturasi).
+const (
+	treeCountBitLimit = 1024
+	maxTreeCountDepth = 64
+)
+
+var treeCountCap = new(big.Int).Lsh(big.NewInt(1), treeCountBitLimit)
+
+// Pohon derivasi: elemen dasar adalah daun, elemen lain memilih satu resep dengan subpohon untuk
+// kedua bahannya. Kedalaman elemen dasar 0, resep dari dua elemen dasar berkedalaman 1.
+type RecipeTreeCount struct {
+	Recipe             Recipe `json:"recipe"`
+	Infinite           bool   `json:"infinite"`
+	Total              string `json:"total,omitempty"`
+	TotalSaturated     bool   `json:"totalSaturated,omitempty"`
+	UpToDepth          string `json:"upToDepth"`
+	UpToDepthSaturated bool   `json:"upToDepthSaturated,omitempty"`
+}
+
+type TreeCount struct {
+	Element            string            `json:"element"`
+	Infinite           bool              `json:"infinite"`
+	Total              string            `json:"total,omitempty"`
+	TotalSaturated     bool              `json:"totalSaturated,omitempty"`
+	MinDepth           int               `json:"minDepth"`
+	Depth              int               `json:"depth"`
+	UpToDepth          string            `json:"upToDepth"`
+	UpToDepthSaturated bool              `json:"upToDepthSaturated,omitempty"`
+	Recipes            []RecipeTreeCount `json:"recipes"`
+}
+
+func saturateCount(x *big.Int) *big.Int {
+	if x.Cmp(treeCountCap) > 0 {
+		return x.Set(treeCountCap)
+	}
+	return x
+}
+
+func multiplyCounts(a, b *big.Int) *big.Int {
+	return saturateCount(new(big.Int).Mul(a, b))
+}
+
+func formatCount(x *big.Int) (string, bool) {
+	return x.String(), x.Cmp(treeCountCap) >= 0
+}
+
+// dependencyClosure mengumpulkan target beserta semua elemen yang muncul di bawahnya pada graf resep.
+func dependencyClosure(target string, recipesByResult map[string][]Recipe) []string {
+	visited := map[string]bool{target: true}
+	order := []string{target}
+	for i := 0; i < len(order); i++ {
+		for _, recipe := range recipesByResult[order[i]] {
+			for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+				if !visited[ingredient] {
+					visited[ingredient] = true
+					order = append(order, ingredient)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// countTotalTrees menghitung jumlah seluruh pohon derivasi. Jika ada siklus di antara elemen yang bisa
+// dibuat di bawah target, jumlahnya tak hingga dan infinite bernilai true.
+func countTotalTrees(target string, recipesByResult map[string][]Recipe, minSizes map[string]int) (map[string]*big.Int, map[string]bool) {
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int)
+	totals := make(map[string]*big.Int)
+	infinite := make(map[string]bool)
+
+	var visit func(element string)
+	visit = func(element string) {
+		state[element] = inProgress
+		total := new(big.Int)
+		if isBaseElement(element) {
+			total.SetInt64(1)
+			totals[element] = total
+			state[element] = done
+			return
+		}
+		for _, recipe := range recipesByResult[element] {
+			_, ok1 := minSizes[recipe.Ingredient1]
+			_, ok2 := minSizes[recipe.Ingredient2]
+			if !ok1 || !ok2 {
+				continue
+			}
+			for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+				switch state[ingredient] {
+				case unvisited:
+					visit(ingredient)
+				case inProgress:
+					infinite[element] = true
+				}
+				if infinite[ingredient] {
+					infinite[element] = true
+				}
+			}
+			if infinite[element] {
+				continue
+			}
+			total.Add(total, multiplyCounts(totals[recipe.Ingredient1], totals[recipe.Ingredient2]))
+			saturateCount(total)
+		}
+		totals[element] = total
+		state[element] = done
+	}
+	visit(target)
+	return totals, infinite
+}
+
+// countTreesByDepth mengembalikan jumlah pohon berkedalaman paling banyak d untuk d = 0..depth.
+func countTreesByDepth(closure []string, recipesByResult map[string][]Recipe, depth int) []map[string]*big.Int {
+	levels := make([]map[string]*big.Int, 0, depth+1)
+	current := make(map[string]*big.Int, len(closure))
+	for _, element := range closure {
+		if isBaseElement(element) {
+			current[element] = big.NewInt(1)
+		} else {
+			current[element] = new(big.Int)
+		}
+	}
+	levels = append(levels, current)
+
+	for d := 1; d <= depth; d++ {
+		previous := current
+		current = make(map[string]*big.Int, len(closure))
+		for _, element := range closure {
+			if isBaseElement(element) {
+				current[element] = previous[element]
+				continue
+			}
+			count := new(big.Int)
+			for _, recipe := range recipesByResult[element] {
+				count.Add(count, multiplyCounts(previous[recipe.Ingredient1], previous[recipe.Ingredient2]))
+			}
+			current[element] = saturateCount(count)
+		}
+		levels = append(levels, current)
+	}
+	return levels
+}
+
+// CountRecipeTrees menghitung banyaknya pohon resep untuk sebuah elemen: total, sampai kedalaman tertentu,
+// dan rinciannya per resep teratas. depth <= 0 berarti memakai kedalaman minimum elemen tersebut.
+func CountRecipeTrees(target string, depth int) (TreeCount, error) {
+	recipesByResult := GetRecipeMap()
+	if recipesByResult == nil {
+		return TreeCount{}, errors.New("map resep belum diinisialisasi")
+	}
+	if depth > maxTreeCountDepth {
+		return TreeCount{}, fmt.Errorf("kedalaman maksimal %d", maxTreeCountDepth)
+	}
+
+	minSizes := calculateMinTreeSizes(recipesByResult)
+	result := TreeCount{Element: target, Recipes: []RecipeTreeCount{}}
+	if _, reachable := minSizes[target]; !reachable {
+		return TreeCount{}, fmt.Errorf("elemen '%s' tidak dapat dibuat dari elemen dasar", target)
+	}
+
+	totals, infinite := countTotalTrees(target, recipesByResult, minSizes)
+	result.Infinite = infinite[target]
+	if !result.Infinite {
+		result.Total, result.TotalSaturated = formatCount(totals[target])
+	}
+
+	// Kedalaman minimum selalu ditemukan dalam |closure| level karena target bisa dibuat.
+	closure := dependencyClosure(target, recipesByResult)
+	searchDepth := max(depth, min(len(closure), maxTreeCountDepth))
+	levels := countTreesByDepth(closure, recipesByResult, searchDepth)
+	for d, level := range levels {
+		if level[target].Sign() > 0 {
+			result.MinDepth = d
+			break
+		}
+	}
+	if depth <= 0 {
+		depth = result.MinDepth
+	}
+	result.Depth = depth
+	result.UpToDepth, result.UpToDepthSaturated = formatCount(levels[depth][target])
+
+	if isBaseElement(target) {
+		return result, nil
+	}
+	for _, recipe := range recipesByResult[target] {
+		_, ok1 := minSizes[recipe.Ingredient1]
+		_, ok2 := minSizes[recipe.Ingredient2]
+		if !ok1 || !ok2 {
+			continue
+		}
+		entry := RecipeTreeCount{
+			Recipe:   recipe,
+			Infinite: infinite[recipe.Ingredient1] || infinite[recipe.Ingredient2],
+		}
+		if !entry.Infinite {
+			entry.Total, entry.TotalSaturated = formatCount(multiplyCounts(totals[recipe.Ingredient1], totals[recipe.Ingredient2]))
+		}
+		upToDepth := new(big.Int)
+		if depth > 0 {
+			upToDepth = multiplyCounts(levels[depth-1][recipe.Ingredient1], levels[depth-1][recipe.Ingredient2])
+		}
+		entry.UpToDepth, entry.UpToDepthSaturated = formatCount(upToDepth)
+		result.Recipes = append(result.Recipes, entry)
+	}
+	return result, nil
+}
+
+// treeCountCache menyimpan jumlah pohon resep per elemen untuk satu versi dataset, karena
+// boundMaxRecipesByTreeCount dipanggil setiap pencarian sebelum kapasitas pencarian diambil. Nilai -1 berarti
+// jumlahnya tak hingga, melebihi int64, atau elemen tidak dapat dibuat.
+var treeCountCache struct {
+	sync.Mutex
+	version string
+	totals  map[string]int64
+}
+
+func cachedTreeCount(target string) int64 {
+	dataset := getDatasetState()
+	treeCountCache.Lock()
+	if treeCountCache.version != dataset.version {
+		treeCountCache.version = dataset.version
+		treeCountCache.totals = make(map[string]int64)
+	}
+	count, exists := treeCountCache.totals[target]
+	treeCountCache.Unlock()
+	if exists {
+		return count
+	}
+
+	count = -1
+	minSizes := getAStarHeuristics(dataset).minSizes
+	if _, reachable := minSizes[target]; reachable {
+		totals, infinite := countTotalTrees(target, dataset.recipeMap, minSizes)
+		if !infinite[target] && totals[target].IsInt64() {
+			count = totals[target].Int64()
+		}
+	}
+	if dataset.allElementNames[target] {
+		treeCountCache.Lock()
+		if treeCountCache.version == dataset.version {
+			treeCountCache.totals[target] = count
+		}
+		treeCountCache.Unlock()
+	}
+	return count
+}
+
+// boundMaxRecipesByTreeCount menurunkan 'max' jika elemen hanya punya sedikit pohon resep berbeda.
+func boundMaxRecipesByTreeCount(target string, maxRecipes int) int {
+	total := cachedTreeCount(target)
+	if total < 0 || total >= int64(maxRecipes) {
+		return maxRecipes
+	}
+	bound := max(int(total), 1)
+	log.Printf("Parameter max=%d melebihi jumlah pohon resep '%s' (%d), dipotong menjadi %d\n", maxRecipes, target, bound, bound)
+	return bound
+}
+
+func countHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	targetElement := resolveElementName(r.URL.Query().Get("target"))
+	if err := validateSearchParams(targetElement, "bfs", "shortest"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	depth := 0
+	if depthStr := r.URL.Query().Get("depth"); depthStr != "" {
+		var convErr error
+		depth, convErr = strconv.Atoi(depthStr)
+		if convErr != nil || depth <= 0 || depth > maxTreeCountDepth {
+			http.Error(w, fmt.Sprintf("Parameter 'depth' harus berupa angka antara 1 dan %d", maxTreeCountDepth), http.StatusBadRequest)
+			return
+		}
+	}
+
+	count, err := CountRecipeTrees(targetElement, depth)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	writeJSON(w, count)
+}
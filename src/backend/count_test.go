@@ -0,0 +1,71 @@
+// src/backend/count_test.go
+package main
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCountRecipeTreesFiniteAndInfinite(t *testing.T) {
+	loadTestDataset(t)
+
+	steam, err := CountRecipeTrees("Steam", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if steam.Infinite || steam.Total != "2" || steam.MinDepth != 1 {
+		t.Errorf("Steam: diharapkan 2 pohon berhingga dengan kedalaman minimum 1, didapat %+v", steam)
+	}
+	sum := new(big.Int)
+	for _, entry := range steam.Recipes {
+		value, _ := new(big.Int).SetString(entry.Total, 10)
+		sum.Add(sum, value)
+	}
+	if sum.String() != steam.Total {
+		t.Errorf("Steam: jumlah rincian per resep %s tidak sama dengan total %s", sum, steam.Total)
+	}
+
+	human, err := CountRecipeTrees("Human", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !human.Infinite || human.Total != "" {
+		t.Errorf("Human seharusnya punya pohon tak hingga karena siklus resep")
+	}
+	deeper, _ := CountRecipeTrees("Human", human.MinDepth+1)
+	shallow, _ := new(big.Int).SetString(human.UpToDepth, 10)
+	more, _ := new(big.Int).SetString(deeper.UpToDepth, 10)
+	if shallow.Sign() <= 0 || more.Cmp(shallow) <= 0 {
+		t.Errorf("Human: jumlah pohon harus bertambah dengan kedalaman (%s -> %s)", shallow, more)
+	}
+}
+
+func TestCountRecipeTreesBoundsKBest(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range []string{"Mud", "Brick", "Steam"} {
+		count, err := CountRecipeTrees(element, 0)
+		if err != nil || count.Infinite {
+			t.Fatalf("%s: diharapkan jumlah berhingga: %v", element, err)
+		}
+		total, _ := new(big.Int).SetString(count.Total, 10)
+		result, _, err := FindKBestPaths(element, int(total.Int64())+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.Exhausted || int64(len(result.Paths)) > total.Int64() {
+			t.Errorf("%s: k-best menemukan %d pohon, melebihi hitungan %s", element, len(result.Paths), count.Total)
+		}
+		if bound := boundMaxRecipesByTreeCount(element, 50); int64(bound) != total.Int64() {
+			t.Errorf("%s: max seharusnya dipotong menjadi %s, didapat %d", element, count.Total, bound)
+		}
+		// Pemanggilan berikutnya memakai cache per versi dataset dan tetap menghasilkan batas yang sama.
+		if cached, exists := treeCountCache.totals[element]; !exists || cached != total.Int64() || treeCountCache.version != GetDatasetVersion() {
+			t.Errorf("%s: jumlah pohon seharusnya tersimpan di cache, didapat %d (%v)", element, cached, exists)
+		}
+		if bound := boundMaxRecipesByTreeCount(element, 50); int64(bound) != total.Int64() {
+			t.Errorf("%s: batas dari cache berbeda: %d", element, bound)
+		}
+	}
+}
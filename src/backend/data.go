@@ -2,10 +2,13 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 )
 
@@ -18,32 +21,37 @@ type Recipe struct {
 var (
 	recipeMap       map[string][]Recipe
 	allElementNames map[string]bool
+	datasetVersion  string
 
-	bfsPathCache = make(map[string][]Recipe)
-	loadDataOnce sync.Once
-	loadDataErr  error
+	// datasetMu melindungi data di atas dan alchemyGraph agar dataset bisa diganti saat server berjalan.
+	datasetMu sync.RWMutex
+
+	// sharedBFSPathCache adalah cache jalur BFS yang dipakai bersama semua permintaan pencarian.
+	sharedBFSPathCache = newBFSPathCache()
+	loadDataOnce       sync.Once
+	loadDataErr        error
 )
 
 func InitData(dataDir string) error {
 	loadDataOnce.Do(func() {
-		fmt.Println("Memulai pemuatan data awal dari direktori:", dataDir)
+		traceln("Memulai pemuatan data awal dari direktori:", dataDir)
 
 		tempRecipes, err := loadRecipes(filepath.Join(dataDir, "recipes_final_filtered.json"))
 		if err != nil {
 			loadDataErr = fmt.Errorf("gagal memuat resep: %w", err)
 			return
 		}
-		fmt.Printf("Berhasil memuat %d data resep.\n", len(tempRecipes))
+		tracef("Berhasil memuat %d data resep.\n", len(tempRecipes))
 
-		fmt.Println("Memproses data resep ke dalam struktur map...")
+		traceln("Memproses data resep ke dalam struktur map...")
 		processRecipesToMaps(tempRecipes)
-		fmt.Println("Selesai memproses data resep.")
+		traceln("Selesai memproses data resep.")
 	})
 	return loadDataErr
 }
 
 func loadRecipes(filePath string) ([]Recipe, error) {
-	fmt.Printf("Membaca file resep: %s\n", filePath)
+	tracef("Membaca file resep: %s\n", filePath)
 	bytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
@@ -56,34 +64,124 @@ func loadRecipes(filePath string) ([]Recipe, error) {
 	return recipes, nil
 }
 
-func processRecipesToMaps(recipes []Recipe) {
-	recipeMap = make(map[string][]Recipe)
-	allElementNames = make(map[string]bool)
+// loadElementImageURLs membaca hasil scraping gambar menjadi map nama elemen -> URL gambar.
+func loadElementImageURLs(filePath string) (map[string]string, error) {
+	bytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca file %s: %w", filePath, err)
+	}
+	var images []ElementImage
+	if err := json.Unmarshal(bytes, &images); err != nil {
+		return nil, fmt.Errorf("gagal unmarshal JSON gambar dari %s: %w", filePath, err)
+	}
+	imageURLs := make(map[string]string, len(images))
+	for _, image := range images {
+		if _, exists := imageURLs[image.Name]; !exists {
+			imageURLs[image.Name] = image.ImageURL
+		}
+	}
+	return imageURLs, nil
+}
+
+// datasetState adalah satu dataset lengkap yang sudah dibangun dan siap dipasang.
+type datasetState struct {
+	recipeMap       map[string][]Recipe
+	allElementNames map[string]bool
+	version         string
+}
+
+func buildDatasetState(recipes []Recipe) datasetState {
+	newRecipeMap := make(map[string][]Recipe)
+	newElementNames := make(map[string]bool)
 
 	for _, r := range recipes {
-		recipeMap[r.Result] = append(recipeMap[r.Result], r)
-		allElementNames[r.Result] = true
-		allElementNames[r.Ingredient1] = true
-		allElementNames[r.Ingredient2] = true
+		newRecipeMap[r.Result] = append(newRecipeMap[r.Result], r)
+		newElementNames[r.Result] = true
+		newElementNames[r.Ingredient1] = true
+		newElementNames[r.Ingredient2] = true
 	}
 
 	baseElements := []string{"Air", "Earth", "Fire", "Water"}
 	for _, base := range baseElements {
-		allElementNames[base] = true
+		newElementNames[base] = true
 	}
 
-	fmt.Printf("Total elemen unik yang teridentifikasi dari resep: %d\n", len(allElementNames))
+	tracef("Total elemen unik yang teridentifikasi dari resep: %d\n", len(newElementNames))
+	return datasetState{recipeMap: newRecipeMap, allElementNames: newElementNames, version: computeDatasetVersion(recipes)}
+}
+
+func processRecipesToMaps(recipes []Recipe) {
+	state := buildDatasetState(recipes)
+
+	datasetMu.Lock()
+	recipeMap = state.recipeMap
+	allElementNames = state.allElementNames
+	datasetVersion = state.version
+	datasetMu.Unlock()
+}
+
+// ReplaceDataset mengganti dataset yang sedang dipakai server: map resep, graf, dan cache pencarian.
+// Semua state baru dibangun lebih dulu lalu dipasang dalam satu lock, jadi pembaca tidak pernah melihat
+// map baru dengan graf atau cache lama. Pencarian yang sedang berjalan tetap memakai state lama yang tidak
+// pernah diubah.
+func ReplaceDataset(recipes []Recipe) {
+	state := buildDatasetState(recipes)
+	graph := buildAlchemyGraph(state.recipeMap)
+	compact := buildCompactGraph(state.recipeMap)
+	cache := newBFSPathCache()
+
+	datasetMu.Lock()
+	recipeMap = state.recipeMap
+	allElementNames = state.allElementNames
+	datasetVersion = state.version
+	alchemyGraph = graph
+	compactGraph = compact
+	sharedBFSPathCache = cache
+	datasetMu.Unlock()
+}
+
+// computeDatasetVersion menghasilkan hash isi dataset yang tidak bergantung pada urutan resep di file.
+func computeDatasetVersion(recipes []Recipe) string {
+	ids := make([]string, 0, len(recipes))
+	for _, r := range recipes {
+		ids = append(ids, getRecipeID(r))
+	}
+	sort.Strings(ids)
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte(id))
+		hash.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// getDatasetState mengembalikan map resep, nama elemen, dan versi dataset dari satu snapshot, sehingga
+// cache per versi tidak menyimpan hasil map lama di bawah versi baru ketika dataset diganti.
+func getDatasetState() datasetState {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
+	return datasetState{recipeMap: recipeMap, allElementNames: allElementNames, version: datasetVersion}
 }
 
 func GetRecipeMap() map[string][]Recipe {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
 	return recipeMap
 }
 
+func GetDatasetVersion() string {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
+	return datasetVersion
+}
+
 func GetAllElementNames() map[string]bool {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
 	return allElementNames
 }
 
 func IsElementExists(name string) bool {
-	_, exists := allElementNames[name]
+	_, exists := GetAllElementNames()[name]
 	return exists
 }
@@ -7,711 +7,411 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
-func FindPathDFS(targetElement string) ([]Recipe, int, error) {
-	fmt.Printf("Mencari jalur DFS (single) ke: %s\n", targetElement)
+func init() {
+	RegisterSearcher(funcSearcher{
+		name:         "dfs",
+		description:  "Depth First Search dari elemen target ke elemen dasar",
+		capabilities: SearchCapabilities{Modes: []string{"shortest", "multiple"}},
+		shortest:     FindPathDFS,
+		multiple:     multiplePathsFunc(FindMultiplePathsDFS),
+		// findAlternativePaths dibatasi semaphore berisi 8
+		cost: func(maxRecipes int) int64 { return int64(min(maxRecipes, 8) + 1) },
+	})
+}
 
-	// Persiapan
-	recipeMap := GetRecipeMap()
-	if recipeMap == nil {
-		return nil, 0, errors.New("map resep belum diinisialisasi")
+// dfsPlanner menyimpan memo DFS berbasis ID CompactGraph. FindMultiplePathsDFS memakainya dari banyak
+// goroutine sekaligus, sehingga memo dilindungi mutex dan penghitung node memakai atomic.
+type dfsPlanner struct {
+	g *CompactGraph
+	// creatable: 0 belum diketahui, 1 bisa dibuat, -1 tidak bisa dibuat.
+	creatable []int8
+	pathCache [][]int32
+	mu        sync.RWMutex
+	nodes     atomic.Int64
+}
+
+func newDFSPlanner(g *CompactGraph) *dfsPlanner {
+	return &dfsPlanner{
+		g:         g,
+		creatable: make([]int8, g.NumElements()),
+		pathCache: make([][]int32, g.NumElements()),
 	}
+}
 
-	if isBaseElementDFS(targetElement) {
-		return []Recipe{}, 0, nil
+// newElementSet membuat penanda elemen yang tersedia, berisi elemen dasar.
+func (p *dfsPlanner) newElementSet() []bool {
+	available := make([]bool, p.g.NumElements())
+	for id := range available {
+		available[id] = p.g.IsBase(int32(id))
 	}
+	return available
+}
 
-	nodesVisitedCount := 0
+func (p *dfsPlanner) isCreatable(element int32, visited []bool, depth int) bool {
+	p.nodes.Add(1)
 
-	knownCreatableElements := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		knownCreatableElements[base] = true
+	if depth > 500 {
+		return false
+	}
+	if p.g.IsBase(element) {
+		return true
 	}
 
-	pathCache := make(map[string][]Recipe)
-
-	var isCreatable func(element string, visited map[string]bool, depth int) bool
-	isCreatable = func(element string, visited map[string]bool, depth int) bool {
-		nodesVisitedCount++
+	p.mu.RLock()
+	known := p.creatable[element]
+	p.mu.RUnlock()
+	if known != 0 {
+		return known > 0
+	}
 
-		if depth > 500 {
-			return false
-		}
+	if depth > 30 && visited[element] {
+		return false
+	}
+	wasVisited := visited[element]
+	visited[element] = true
+	defer func() { visited[element] = wasVisited }()
 
-		if isBaseElementDFS(element) {
-			return true
-		}
+	first, last := p.g.Producers(element)
+	if first == last {
+		p.setCreatable(element, false)
+		return false
+	}
 
-		if known, exists := knownCreatableElements[element]; exists {
-			return known
-		}
+	for rid := first; rid < last; rid++ {
+		recipe := p.g.recipes[rid]
+		ing1Creatable := p.isCreatable(recipe.ingredient1, visited, depth+1)
+		ing2Creatable := p.isCreatable(recipe.ingredient2, visited, depth+1)
 
-		if depth > 30 && visited[element] {
-			return false
+		if ing1Creatable && ing2Creatable {
+			p.setCreatable(element, true)
+			return true
 		}
+	}
 
-		newVisited := make(map[string]bool)
-		for k, v := range visited {
-			newVisited[k] = v
-		}
-		newVisited[element] = true
+	p.setCreatable(element, false)
+	return false
+}
 
-		recipes := recipeMap[element]
-		if len(recipes) == 0 {
-			knownCreatableElements[element] = false
-			return false
-		}
+func (p *dfsPlanner) setCreatable(element int32, creatable bool) {
+	p.mu.Lock()
+	if creatable {
+		p.creatable[element] = 1
+	} else {
+		p.creatable[element] = -1
+	}
+	p.mu.Unlock()
+}
 
-		for _, recipe := range recipes {
-			ing1Creatable := isCreatable(recipe.Ingredient1, newVisited, depth+1)
-			ing2Creatable := isCreatable(recipe.Ingredient2, newVisited, depth+1)
+// buildOrderedPath mengembalikan resep terurut untuk membuat target dari elemen yang sudah tersedia.
+// nil berarti tidak ada jalur; slice kosong berarti target sudah tersedia. available dan visited tidak
+// diubah (visited dipulihkan sebelum kembali).
+func (p *dfsPlanner) buildOrderedPath(target int32, available []bool, visited []bool) []int32 {
+	p.nodes.Add(1)
+	if p.g.IsBase(target) || available[target] {
+		return []int32{}
+	}
 
-			if ing1Creatable && ing2Creatable {
-				knownCreatableElements[element] = true
-				return true
+	p.mu.RLock()
+	path := p.pathCache[target]
+	p.mu.RUnlock()
+	if path != nil {
+		clonedAvailable := append([]bool(nil), available...)
+		valid := true
+		for _, rid := range path {
+			recipe := p.g.recipes[rid]
+			if !clonedAvailable[recipe.ingredient1] || !clonedAvailable[recipe.ingredient2] {
+				valid = false
+				break
 			}
+			clonedAvailable[recipe.result] = true
 		}
-
-		knownCreatableElements[element] = false
-		return false
-	}
-
-	var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe
-	buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe {
-		nodesVisitedCount++
-		if isBaseElementDFS(target) || availableElements[target] {
-			return []Recipe{}
+		if valid {
+			return append([]int32(nil), path...)
 		}
+	}
 
-		if path, exists := pathCache[target]; exists {
-			clonedAvailable := make(map[string]bool)
-			for k, v := range availableElements {
-				clonedAvailable[k] = v
-			}
-
-			valid := true
-			for _, recipe := range path {
-				if !isBaseElementDFS(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
-					valid = false
-					break
-				}
-				if !isBaseElementDFS(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
-					valid = false
-					break
-				}
-				clonedAvailable[recipe.Result] = true
-			}
+	if visited[target] {
+		return nil
+	}
+	visited[target] = true
+	defer func() { visited[target] = false }()
 
-			if valid {
-				pathCopy := make([]Recipe, len(path))
-				copy(pathCopy, path)
-				return pathCopy
-			}
-		}
+	first, last := p.g.Producers(target)
+	if first == last {
+		return nil
+	}
+	recipes := make([]int32, 0, last-first)
+	for rid := first; rid < last; rid++ {
+		recipes = append(recipes, rid)
+	}
 
-		if visited[target] {
-			return nil
+	canMake := func(rid int32) bool {
+		recipe := p.g.recipes[rid]
+		return available[recipe.ingredient1] && available[recipe.ingredient2]
+	}
+	baseCount := func(rid int32) int {
+		recipe := p.g.recipes[rid]
+		count := 0
+		if p.g.IsBase(recipe.ingredient1) {
+			count++
 		}
-
-		newVisited := make(map[string]bool)
-		for k, v := range visited {
-			newVisited[k] = v
+		if p.g.IsBase(recipe.ingredient2) {
+			count++
 		}
-		newVisited[target] = true
-
-		recipes := recipeMap[target]
-		if len(recipes) == 0 {
-			return nil
+		return count
+	}
+	// Resep yang bahannya sudah tersedia lebih dulu, lalu yang memakai lebih banyak elemen dasar.
+	sort.Slice(recipes, func(i, j int) bool {
+		iCanMake, jCanMake := canMake(recipes[i]), canMake(recipes[j])
+		if iCanMake != jCanMake {
+			return iCanMake
 		}
+		return baseCount(recipes[i]) > baseCount(recipes[j])
+	})
 
-		sort.Slice(recipes, func(i, j int) bool {
-			iCanMake := (isBaseElementDFS(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
-				(isBaseElementDFS(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
-			jCanMake := (isBaseElementDFS(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
-				(isBaseElementDFS(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
-
-			if iCanMake && !jCanMake {
-				return true
-			}
-			if !iCanMake && jCanMake {
-				return false
-			}
-
-			iBaseCount := 0
-			jBaseCount := 0
-
-			if isBaseElementDFS(recipes[i].Ingredient1) {
-				iBaseCount++
-			}
-			if isBaseElementDFS(recipes[i].Ingredient2) {
-				iBaseCount++
-			}
-			if isBaseElementDFS(recipes[j].Ingredient1) {
-				jBaseCount++
-			}
-			if isBaseElementDFS(recipes[j].Ingredient2) {
-				jBaseCount++
-			}
-
-			if iBaseCount != jBaseCount {
-				return iBaseCount > jBaseCount
-			}
-
-			return recipes[i].Result < recipes[j].Result // Stabil sort
-		})
-
-		var bestPath []Recipe
-
-		for _, recipe := range recipes {
-			elementsAvailable := make(map[string]bool)
-			for k, v := range availableElements {
-				elementsAvailable[k] = v
-			}
-
-			var path1 []Recipe
-			if !isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
-				path1 = buildOrderedPath(recipe.Ingredient1, elementsAvailable, newVisited)
-				if path1 == nil {
-					continue
-				}
+	var bestPath []int32
+	for _, rid := range recipes {
+		recipe := p.g.recipes[rid]
+		elementsAvailable := append([]bool(nil), available...)
 
-				for _, p := range path1 {
-					elementsAvailable[p.Result] = true
-				}
+		var path1 []int32
+		if !elementsAvailable[recipe.ingredient1] {
+			path1 = p.buildOrderedPath(recipe.ingredient1, elementsAvailable, visited)
+			if path1 == nil {
+				continue
 			}
-
-			var path2 []Recipe
-			if !isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
-				path2 = buildOrderedPath(recipe.Ingredient2, elementsAvailable, newVisited)
-				if path2 == nil {
-					continue
-				}
-
-				for _, p := range path2 {
-					elementsAvailable[p.Result] = true
-				}
+			for _, step := range path1 {
+				elementsAvailable[p.g.recipes[step].result] = true
 			}
+		}
 
-			if (!isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
-				(!isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
+		var path2 []int32
+		if !elementsAvailable[recipe.ingredient2] {
+			path2 = p.buildOrderedPath(recipe.ingredient2, elementsAvailable, visited)
+			if path2 == nil {
 				continue
 			}
-
-			completePath := make([]Recipe, 0)
-
-			if path1 != nil {
-				completePath = append(completePath, path1...)
-			}
-
-			if path2 != nil {
-				completePath = append(completePath, path2...)
+			for _, step := range path2 {
+				elementsAvailable[p.g.recipes[step].result] = true
 			}
+		}
 
-			completePath = append(completePath, recipe)
-
-			if bestPath == nil || len(completePath) < len(bestPath) {
-				bestPath = completePath
-			}
+		if !elementsAvailable[recipe.ingredient1] || !elementsAvailable[recipe.ingredient2] {
+			continue
 		}
 
-		if bestPath != nil {
-			pathCopy := make([]Recipe, len(bestPath))
-			copy(pathCopy, bestPath)
-			pathCache[target] = pathCopy
+		completePath := make([]int32, 0, len(path1)+len(path2)+1)
+		completePath = append(completePath, path1...)
+		completePath = append(completePath, path2...)
+		completePath = append(completePath, rid)
+
+		if bestPath == nil || len(completePath) < len(bestPath) {
+			bestPath = completePath
 		}
+	}
 
-		return bestPath
+	if bestPath != nil {
+		p.mu.Lock()
+		p.pathCache[target] = append([]int32(nil), bestPath...)
+		p.mu.Unlock()
 	}
+	return bestPath
+}
 
-	var removeDuplicateRecipes = func(path []Recipe) []Recipe {
-		seen := make(map[string]bool)
-		unique := make([]Recipe, 0, len(path))
+// removeDuplicateRecipes membuang resep yang muncul lebih dari sekali, dengan urutan kemunculan pertama.
+func removeDuplicateRecipes(path []int32) []int32 {
+	seen := make(map[int32]bool, len(path))
+	unique := make([]int32, 0, len(path))
+	for _, rid := range path {
+		if !seen[rid] {
+			seen[rid] = true
+			unique = append(unique, rid)
+		}
+	}
+	return unique
+}
 
-		for _, recipe := range path {
-			key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
-			if !seen[key] {
-				seen[key] = true
-				unique = append(unique, recipe)
+// isOrderedPath memeriksa bahwa setiap bahan sudah tersedia sebelum resep yang memakainya.
+func (p *dfsPlanner) isOrderedPath(path []int32, warn bool) bool {
+	available := p.newElementSet()
+	valid := true
+	for i, rid := range path {
+		recipe := p.g.recipes[rid]
+		for _, ingredient := range []int32{recipe.ingredient1, recipe.ingredient2} {
+			if !available[ingredient] {
+				valid = false
+				if warn {
+					tracef("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n", p.g.Name(ingredient), i+1)
+				}
 			}
 		}
-
-		return unique
+		available[recipe.result] = true
 	}
+	return valid
+}
 
-	availableElements := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		availableElements[base] = true
+// optimalPath menjalankan buildOrderedPath dari elemen dasar lalu merapikan hasilnya.
+func (p *dfsPlanner) optimalPath(target int32) []int32 {
+	tracef("Mencari jalur optimal untuk %s...\n", p.g.Name(target))
+	path := p.buildOrderedPath(target, p.newElementSet(), make([]bool, p.g.NumElements()))
+	if path == nil {
+		return nil
 	}
+	path = removeDuplicateRecipes(path)
+	p.isOrderedPath(path, true)
+	return path
+}
 
-	fmt.Printf("Mencari jalur optimal untuk %s...\n", targetElement)
-	optimalPath := buildOrderedPath(targetElement, availableElements, make(map[string]bool))
-
-	if optimalPath == nil {
-		return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
+func printDFSPath(path []Recipe) {
+	for i, recipe := range path {
+		tracef("  Langkah %d: %s + %s => %s\n",
+			i+1, recipe.Ingredient1, recipe.Ingredient2, recipe.Result)
 	}
+}
 
-	optimalPath = removeDuplicateRecipes(optimalPath)
+func FindPathDFS(targetElement string) ([]Recipe, int, error) {
+	tracef("Mencari jalur DFS (single) ke: %s\n", targetElement)
 
-	available := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		available[base] = true
+	g := GetCompactGraph()
+	if g == nil {
+		return nil, 0, errors.New("map resep belum diinisialisasi")
 	}
-
-	for i, recipe := range optimalPath {
-		if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-			fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n",
-				recipe.Ingredient1, i+1)
-		}
-
-		if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-			fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n",
-				recipe.Ingredient2, i+1)
-		}
-
-		available[recipe.Result] = true
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+	target, exists := g.ID(targetElement)
+	if !exists {
+		return nil, 0, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
 	}
 
-	fmt.Printf("Jalur DFS (single) (panjang: %d):\n", len(optimalPath))
-	for i, recipe := range optimalPath {
-		fmt.Printf("  Langkah %d: %s + %s => %s\n",
-			i+1, recipe.Ingredient1, recipe.Ingredient2, recipe.Result)
+	planner := newDFSPlanner(g)
+	optimalPath := planner.optimalPath(target)
+	if optimalPath == nil {
+		return nil, int(planner.nodes.Load()), fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
 	}
 
-	return optimalPath, nodesVisitedCount, nil
+	path := g.recipesToPath(optimalPath)
+	tracef("Jalur DFS (single) (panjang: %d):\n", len(path))
+	printDFSPath(path)
+	return path, int(planner.nodes.Load()), nil
 }
 
 func FindMultiplePathsDFS(targetElement string, maxRecipes int) ([][]Recipe, int, error) {
-	fmt.Printf("Mencari %d jalur DFS BERBEDA ke: %s dengan multithreading (Super Robust)\n", maxRecipes, targetElement)
+	tracef("Mencari %d jalur DFS BERBEDA ke: %s dengan multithreading (Super Robust)\n", maxRecipes, targetElement)
 
-	recipeMap := GetRecipeMap()
-	if recipeMap == nil {
+	g := GetCompactGraph()
+	if g == nil {
 		return nil, 0, errors.New("map resep belum diinisialisasi")
 	}
 	if maxRecipes <= 0 {
 		return nil, 0, errors.New("jumlah resep minimal harus 1")
 	}
-	if isBaseElementDFS(targetElement) {
+	if isBaseElement(targetElement) {
 		return [][]Recipe{}, 0, nil
 	}
-
-	nodesVisitedCount := 0
-
-	knownCreatableElements := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		knownCreatableElements[base] = true
+	target, exists := g.ID(targetElement)
+	if !exists {
+		return nil, 0, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
 	}
-	var knownCreatableMutex sync.RWMutex
-
-	pathCache := make(map[string][]Recipe)
-	var pathCacheMutex sync.RWMutex
-
-	var isCreatable func(element string, visited map[string]bool, depth int) bool
-	isCreatable = func(element string, visited map[string]bool, depth int) bool {
-		nodesVisitedCount++
-
-		if depth > 500 {
-			return false
-		}
-
-		if isBaseElementDFS(element) {
-			return true
-		}
-
-		knownCreatableMutex.RLock()
-		if known, exists := knownCreatableElements[element]; exists {
-			knownCreatableMutex.RUnlock()
-			return known
-		}
-		knownCreatableMutex.RUnlock()
-
-		if depth > 30 && visited[element] {
-			return false
-		}
 
-		newVisited := make(map[string]bool)
-		for k, v := range visited {
-			newVisited[k] = v
-		}
-		newVisited[element] = true
-
-		recipes := recipeMap[element]
-		if len(recipes) == 0 {
-			knownCreatableMutex.Lock()
-			knownCreatableElements[element] = false
-			knownCreatableMutex.Unlock()
-			return false
-		}
-
-		for _, recipe := range recipes {
-			ing1Creatable := isCreatable(recipe.Ingredient1, newVisited, depth+1)
-			ing2Creatable := isCreatable(recipe.Ingredient2, newVisited, depth+1)
+	planner := newDFSPlanner(g)
+	optimalPath := planner.optimalPath(target)
+	if optimalPath == nil {
+		return nil, int(planner.nodes.Load()), fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
+	}
 
-			if ing1Creatable && ing2Creatable {
-				knownCreatableMutex.Lock()
-				knownCreatableElements[element] = true
-				knownCreatableMutex.Unlock()
-				return true
-			}
-		}
+	firstPath := g.recipesToPath(optimalPath)
+	tracef("Jalur optimal (panjang: %d):\n", len(firstPath))
+	printDFSPath(firstPath)
 
-		knownCreatableMutex.Lock()
-		knownCreatableElements[element] = false
-		knownCreatableMutex.Unlock()
-		return false
+	if maxRecipes <= 1 {
+		return [][]Recipe{firstPath}, int(planner.nodes.Load()), nil
 	}
 
-	var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe
-	buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe {
-		nodesVisitedCount++
-		if isBaseElementDFS(target) || availableElements[target] {
-			return []Recipe{}
-		}
-
-		pathCacheMutex.RLock()
-		if path, exists := pathCache[target]; exists {
-			pathCacheMutex.RUnlock()
+	tracef("Mencari %d jalur alternatif...\n", maxRecipes-1)
+	allPaths := planner.findAlternativePaths(target, optimalPath, firstPath, maxRecipes)
 
-			clonedAvailable := make(map[string]bool)
-			for k, v := range availableElements {
-				clonedAvailable[k] = v
-			}
+	sort.Slice(allPaths, func(i, j int) bool {
+		return len(allPaths[i]) < len(allPaths[j])
+	})
 
-			valid := true
-			for _, recipe := range path {
-				if !isBaseElementDFS(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
-					valid = false
-					break
-				}
-				if !isBaseElementDFS(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
-					valid = false
-					break
-				}
-				clonedAvailable[recipe.Result] = true
-			}
+	for i, path := range allPaths {
+		tracef("Jalur %d (panjang: %d):\n", i+1, len(path))
+		printDFSPath(path)
+	}
 
-			if valid {
-				pathCopy := make([]Recipe, len(path))
-				copy(pathCopy, path)
-				return pathCopy
-			}
-		} else {
-			pathCacheMutex.RUnlock()
-		}
+	return allPaths, int(planner.nodes.Load()), nil
+}
 
-		if visited[target] {
-			return nil
-		}
+// findAlternativePaths mencoba setiap resep lain untuk target secara paralel (dibatasi semaphore berisi 8).
+func (p *dfsPlanner) findAlternativePaths(target int32, existing []int32, existingPath []Recipe, maxPaths int) [][]Recipe {
+	results := [][]Recipe{existingPath}
+	uniquePathMap := map[string]bool{generatePathIdentifierDFS(existingPath): true}
 
-		newVisited := make(map[string]bool)
-		for k, v := range visited {
-			newVisited[k] = v
-		}
-		newVisited[target] = true
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, 8)
+	var resultsMutex sync.Mutex
 
-		recipes := recipeMap[target]
-		if len(recipes) == 0 {
-			return nil
+	first, last := p.g.Producers(target)
+	for rid := first; rid < last; rid++ {
+		if len(existing) > 0 && existing[len(existing)-1] == rid {
+			continue
 		}
 
-		sort.Slice(recipes, func(i, j int) bool {
-			iCanMake := (isBaseElementDFS(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
-				(isBaseElementDFS(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
-			jCanMake := (isBaseElementDFS(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
-				(isBaseElementDFS(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
-
-			if iCanMake && !jCanMake {
-				return true
-			}
-			if !iCanMake && jCanMake {
-				return false
-			}
-
-			iBaseCount := 0
-			jBaseCount := 0
-
-			if isBaseElementDFS(recipes[i].Ingredient1) {
-				iBaseCount++
-			}
-			if isBaseElementDFS(recipes[i].Ingredient2) {
-				iBaseCount++
-			}
-			if isBaseElementDFS(recipes[j].Ingredient1) {
-				jBaseCount++
-			}
-			if isBaseElementDFS(recipes[j].Ingredient2) {
-				jBaseCount++
-			}
+		wg.Add(1)
+		go func(rid int32) {
+			semaphore <- struct{}{}
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
 
-			if iBaseCount != jBaseCount {
-				return iBaseCount > jBaseCount
+			recipe := p.g.recipes[rid]
+			if !p.isCreatable(recipe.ingredient1, make([]bool, p.g.NumElements()), 0) ||
+				!p.isCreatable(recipe.ingredient2, make([]bool, p.g.NumElements()), 0) {
+				return
 			}
 
-			return recipes[i].Result < recipes[j].Result
-		})
-
-		var bestPath []Recipe
-
-		for _, recipe := range recipes {
-			elementsAvailable := make(map[string]bool)
-			for k, v := range availableElements {
-				elementsAvailable[k] = v
-			}
-			var path1 []Recipe
-			if !isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
-				path1 = buildOrderedPath(recipe.Ingredient1, elementsAvailable, newVisited)
-				if path1 == nil {
+			available := p.newElementSet()
+			var completePath []int32
+			for _, ingredient := range []int32{recipe.ingredient1, recipe.ingredient2} {
+				if available[ingredient] {
 					continue
 				}
-
-				for _, p := range path1 {
-					elementsAvailable[p.Result] = true
-				}
-			}
-
-			var path2 []Recipe
-			if !isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
-				path2 = buildOrderedPath(recipe.Ingredient2, elementsAvailable, newVisited)
-				if path2 == nil {
-					continue
+				ingredientPath := p.buildOrderedPath(ingredient, available, make([]bool, p.g.NumElements()))
+				if ingredientPath == nil {
+					return
 				}
-
-				for _, p := range path2 {
-					elementsAvailable[p.Result] = true
+				completePath = append(completePath, ingredientPath...)
+				for _, step := range ingredientPath {
+					available[p.g.recipes[step].result] = true
 				}
 			}
 
-			if (!isBaseElementDFS(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
-				(!isBaseElementDFS(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
-				continue
-			}
-
-			completePath := make([]Recipe, 0)
-
-			if path1 != nil {
-				completePath = append(completePath, path1...)
-			}
-
-			if path2 != nil {
-				completePath = append(completePath, path2...)
-			}
-
-			completePath = append(completePath, recipe)
-
-			if bestPath == nil || len(completePath) < len(bestPath) {
-				bestPath = completePath
-			}
-		}
-
-		if bestPath != nil {
-			pathCopy := make([]Recipe, len(bestPath))
-			copy(pathCopy, bestPath)
-
-			pathCacheMutex.Lock()
-			pathCache[target] = pathCopy
-			pathCacheMutex.Unlock()
-		}
-
-		return bestPath
-	}
-
-	var removeDuplicateRecipes = func(path []Recipe) []Recipe {
-		seen := make(map[string]bool)
-		unique := make([]Recipe, 0, len(path))
-
-		for _, recipe := range path {
-			key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
-			if !seen[key] {
-				seen[key] = true
-				unique = append(unique, recipe)
+			completePath = append(completePath, rid)
+			finalPath := removeDuplicateRecipes(completePath)
+			if !p.isOrderedPath(finalPath, false) {
+				return
 			}
-		}
-
-		return unique
-	}
-
-	var findAlternativePaths = func(target string, existingPath []Recipe, maxPaths int) [][]Recipe {
-		results := [][]Recipe{existingPath}
-		uniquePathMap := make(map[string]bool)
 
-		existingPathID := generatePathIdentifierDFS(existingPath)
-		uniquePathMap[existingPathID] = true
+			path := p.g.recipesToPath(finalPath)
+			pathID := generatePathIdentifierDFS(path)
 
-		var wg sync.WaitGroup
-		semaphore := make(chan struct{}, 8)
-		var resultsMutex sync.Mutex
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
 
-		recipesForTarget := recipeMap[target]
-
-		for _, recipe := range recipesForTarget {
-			if len(existingPath) > 0 {
-				lastRecipe := existingPath[len(existingPath)-1]
-				if lastRecipe.Result == recipe.Result &&
-					lastRecipe.Ingredient1 == recipe.Ingredient1 &&
-					lastRecipe.Ingredient2 == recipe.Ingredient2 {
-					continue
-				}
+			if !uniquePathMap[pathID] && len(results) < maxPaths {
+				uniquePathMap[pathID] = true
+				results = append(results, path)
 			}
-
-			wg.Add(1)
-			go func(r Recipe) {
-				semaphore <- struct{}{}
-				defer func() {
-					<-semaphore
-					wg.Done()
-				}()
-
-				availableElements := make(map[string]bool)
-				for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-					availableElements[base] = true
-				}
-
-				if !isCreatable(r.Ingredient1, make(map[string]bool), 0) ||
-					!isCreatable(r.Ingredient2, make(map[string]bool), 0) {
-					return
-				}
-
-				var completePath []Recipe
-
-				if !isBaseElementDFS(r.Ingredient1) {
-					ing1Path := buildOrderedPath(r.Ingredient1, availableElements, make(map[string]bool))
-					if ing1Path == nil {
-						return
-					}
-
-					completePath = append(completePath, ing1Path...)
-
-					for _, p := range ing1Path {
-						availableElements[p.Result] = true
-					}
-				}
-
-				if !isBaseElementDFS(r.Ingredient2) && !availableElements[r.Ingredient2] {
-					ing2Path := buildOrderedPath(r.Ingredient2, availableElements, make(map[string]bool))
-					if ing2Path == nil {
-						return
-					}
-
-					completePath = append(completePath, ing2Path...)
-
-					for _, p := range ing2Path {
-						availableElements[p.Result] = true
-					}
-				}
-
-				completePath = append(completePath, r)
-				finalPath := removeDuplicateRecipes(completePath)
-
-				available := make(map[string]bool)
-				for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-					available[base] = true
-				}
-
-				valid := true
-				for _, recipe := range finalPath {
-					if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-						valid = false
-						break
-					}
-
-					if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-						valid = false
-						break
-					}
-
-					available[recipe.Result] = true
-				}
-
-				if !valid {
-					return
-				}
-
-				pathID := generatePathIdentifierDFS(finalPath)
-
-				resultsMutex.Lock()
-				defer resultsMutex.Unlock()
-
-				if !uniquePathMap[pathID] && len(results) < maxPaths {
-					uniquePathMap[pathID] = true
-					results = append(results, finalPath)
-				}
-			}(recipe)
-		}
-
-		wg.Wait()
-
-		return results
-	}
-
-	availableElements := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		availableElements[base] = true
-	}
-
-	fmt.Printf("Mencari jalur optimal untuk %s...\n", targetElement)
-	optimalPath := buildOrderedPath(targetElement, availableElements, make(map[string]bool))
-
-	if optimalPath == nil {
-		return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
-	}
-
-	optimalPath = removeDuplicateRecipes(optimalPath)
-
-	available := make(map[string]bool)
-	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
-		available[base] = true
-	}
-
-	for i, recipe := range optimalPath {
-		if !isBaseElementDFS(recipe.Ingredient1) && !available[recipe.Ingredient1] {
-			fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n",
-				recipe.Ingredient1, i+1)
-		}
-
-		if !isBaseElementDFS(recipe.Ingredient2) && !available[recipe.Ingredient2] {
-			fmt.Printf("PERINGATAN: Jalur optimal - bahan %s tidak tersedia pada langkah %d\n",
-				recipe.Ingredient2, i+1)
-		}
-
-		available[recipe.Result] = true
-	}
-
-	fmt.Printf("Jalur optimal (panjang: %d):\n", len(optimalPath))
-	for i, recipe := range optimalPath {
-		fmt.Printf("  Langkah %d: %s + %s => %s\n",
-			i+1, recipe.Ingredient1, recipe.Ingredient2, recipe.Result)
-	}
-
-	if maxRecipes <= 1 {
-		return [][]Recipe{optimalPath}, nodesVisitedCount, nil
-	}
-
-	fmt.Printf("Mencari %d jalur alternatif...\n", maxRecipes-1)
-	allPaths := findAlternativePaths(targetElement, optimalPath, maxRecipes)
-
-	sort.Slice(allPaths, func(i, j int) bool {
-		return len(allPaths[i]) < len(allPaths[j])
-	})
-
-	for i, path := range allPaths {
-		fmt.Printf("Jalur %d (panjang: %d):\n", i+1, len(path))
-		for j, recipe := range path {
-			fmt.Printf("  Langkah %d: %s + %s => %s\n",
-				j+1, recipe.Ingredient1, recipe.Ingredient2, recipe.Result)
-		}
+		}(rid)
 	}
 
-	return allPaths, nodesVisitedCount, nil
-}
-
-func isBaseElementDFS(name string) bool {
-	baseElements := []string{"Air", "Earth", "Fire", "Water"}
-	for _, base := range baseElements {
-		if name == base {
-			return true
-		}
-	}
-	return false
+	wg.Wait()
+	return results
 }
 
 func generatePathIdentifierDFS(path []Recipe) string {
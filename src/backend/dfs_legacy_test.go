@@ -0,0 +1,226 @@
+// src/backend/dfs_legacy_test.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// legacyFindPathDFS adalah FindPathDFS sebelum memakai CompactGraph (map berbasis nama yang disalin di setiap
+// rekursi dan resep diambil dari recipeMap), tanpa log. Disimpan untuk membandingkan hasil dan benchmark
+// dengan versi ID integer.
+func legacyFindPathDFS(targetElement string) ([]Recipe, int, error) {
+	recipeMap := GetRecipeMap()
+	if recipeMap == nil {
+		return nil, 0, errors.New("map resep belum diinisialisasi")
+	}
+
+	if isBaseElement(targetElement) {
+		return []Recipe{}, 0, nil
+	}
+
+	nodesVisitedCount := 0
+
+	pathCache := make(map[string][]Recipe)
+
+	var buildOrderedPath func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe
+	buildOrderedPath = func(target string, availableElements map[string]bool, visited map[string]bool) []Recipe {
+		nodesVisitedCount++
+		if isBaseElement(target) || availableElements[target] {
+			return []Recipe{}
+		}
+
+		if path, exists := pathCache[target]; exists {
+			clonedAvailable := make(map[string]bool)
+			for k, v := range availableElements {
+				clonedAvailable[k] = v
+			}
+
+			valid := true
+			for _, recipe := range path {
+				if !isBaseElement(recipe.Ingredient1) && !clonedAvailable[recipe.Ingredient1] {
+					valid = false
+					break
+				}
+				if !isBaseElement(recipe.Ingredient2) && !clonedAvailable[recipe.Ingredient2] {
+					valid = false
+					break
+				}
+				clonedAvailable[recipe.Result] = true
+			}
+
+			if valid {
+				pathCopy := make([]Recipe, len(path))
+				copy(pathCopy, path)
+				return pathCopy
+			}
+		}
+
+		if visited[target] {
+			return nil
+		}
+
+		newVisited := make(map[string]bool)
+		for k, v := range visited {
+			newVisited[k] = v
+		}
+		newVisited[target] = true
+
+		// Salin dulu, recipeMap dipakai bersama oleh pencarian lain yang berjalan bersamaan
+		recipes := append([]Recipe(nil), recipeMap[target]...)
+		if len(recipes) == 0 {
+			return nil
+		}
+
+		sort.Slice(recipes, func(i, j int) bool {
+			iCanMake := (isBaseElement(recipes[i].Ingredient1) || availableElements[recipes[i].Ingredient1]) &&
+				(isBaseElement(recipes[i].Ingredient2) || availableElements[recipes[i].Ingredient2])
+			jCanMake := (isBaseElement(recipes[j].Ingredient1) || availableElements[recipes[j].Ingredient1]) &&
+				(isBaseElement(recipes[j].Ingredient2) || availableElements[recipes[j].Ingredient2])
+
+			if iCanMake && !jCanMake {
+				return true
+			}
+			if !iCanMake && jCanMake {
+				return false
+			}
+
+			iBaseCount := 0
+			jBaseCount := 0
+
+			if isBaseElement(recipes[i].Ingredient1) {
+				iBaseCount++
+			}
+			if isBaseElement(recipes[i].Ingredient2) {
+				iBaseCount++
+			}
+			if isBaseElement(recipes[j].Ingredient1) {
+				jBaseCount++
+			}
+			if isBaseElement(recipes[j].Ingredient2) {
+				jBaseCount++
+			}
+
+			if iBaseCount != jBaseCount {
+				return iBaseCount > jBaseCount
+			}
+
+			return recipes[i].Result < recipes[j].Result // Stabil sort
+		})
+
+		var bestPath []Recipe
+
+		for _, recipe := range recipes {
+			elementsAvailable := make(map[string]bool)
+			for k, v := range availableElements {
+				elementsAvailable[k] = v
+			}
+
+			var path1 []Recipe
+			if !isBaseElement(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1] {
+				path1 = buildOrderedPath(recipe.Ingredient1, elementsAvailable, newVisited)
+				if path1 == nil {
+					continue
+				}
+
+				for _, p := range path1 {
+					elementsAvailable[p.Result] = true
+				}
+			}
+
+			var path2 []Recipe
+			if !isBaseElement(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2] {
+				path2 = buildOrderedPath(recipe.Ingredient2, elementsAvailable, newVisited)
+				if path2 == nil {
+					continue
+				}
+
+				for _, p := range path2 {
+					elementsAvailable[p.Result] = true
+				}
+			}
+
+			if (!isBaseElement(recipe.Ingredient1) && !elementsAvailable[recipe.Ingredient1]) ||
+				(!isBaseElement(recipe.Ingredient2) && !elementsAvailable[recipe.Ingredient2]) {
+				continue
+			}
+
+			completePath := make([]Recipe, 0)
+
+			if path1 != nil {
+				completePath = append(completePath, path1...)
+			}
+
+			if path2 != nil {
+				completePath = append(completePath, path2...)
+			}
+
+			completePath = append(completePath, recipe)
+
+			if bestPath == nil || len(completePath) < len(bestPath) {
+				bestPath = completePath
+			}
+		}
+
+		if bestPath != nil {
+			pathCopy := make([]Recipe, len(bestPath))
+			copy(pathCopy, bestPath)
+			pathCache[target] = pathCopy
+		}
+
+		return bestPath
+	}
+
+	removeDuplicateRecipes := func(path []Recipe) []Recipe {
+		seen := make(map[string]bool)
+		unique := make([]Recipe, 0, len(path))
+
+		for _, recipe := range path {
+			key := fmt.Sprintf("%s:%s+%s", recipe.Result, recipe.Ingredient1, recipe.Ingredient2)
+			if !seen[key] {
+				seen[key] = true
+				unique = append(unique, recipe)
+			}
+		}
+
+		return unique
+	}
+
+	availableElements := make(map[string]bool)
+	for _, base := range []string{"Air", "Earth", "Fire", "Water"} {
+		availableElements[base] = true
+	}
+
+	optimalPath := buildOrderedPath(targetElement, availableElements, make(map[string]bool))
+
+	if optimalPath == nil {
+		return nil, nodesVisitedCount, fmt.Errorf("tidak ada jalur valid untuk membuat %s", targetElement)
+	}
+
+	optimalPath = removeDuplicateRecipes(optimalPath)
+
+	return optimalPath, nodesVisitedCount, nil
+}
+
+func TestCompactDFSMatchesLegacyDFS(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	for _, element := range elements {
+		path, nodes, err := FindPathDFS(element)
+		legacyPath, legacyNodes, legacyErr := legacyFindPathDFS(element)
+		if (err == nil) != (legacyErr == nil) || nodes != legacyNodes || fmt.Sprint(path) != fmt.Sprint(legacyPath) {
+			t.Errorf("DFS %s berbeda dari versi lama: %d node %v, lama %d node %v", element, nodes, path, legacyNodes, legacyPath)
+		}
+	}
+}
+
+func BenchmarkLegacyFindPathDFS(b *testing.B) {
+	benchmarkShortest(b, legacyFindPathDFS)
+}
@@ -0,0 +1,393 @@
+// src/backend/diff.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// File yang boleh dibandingkan lewat API dibatasi ke folder data.
+var diffDataDir = "data"
+
+type datasetSnapshot struct {
+	Label     string
+	Recipes   []Recipe
+	ImageURLs map[string]string
+}
+
+type TierChange struct {
+	Element    string `json:"element"`
+	TierBefore int    `json:"tierBefore"`
+	TierAfter  int    `json:"tierAfter"`
+}
+
+type ImageURLChange struct {
+	Element string `json:"element"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+}
+
+type DatasetDiffSummary struct {
+	RecipesAdded    int `json:"recipesAdded"`
+	RecipesRemoved  int `json:"recipesRemoved"`
+	ElementsAdded   int `json:"elementsAdded"`
+	ElementsRemoved int `json:"elementsRemoved"`
+	TierChanges     int `json:"tierChanges"`
+	ImageURLChanges int `json:"imageURLChanges"`
+}
+
+type DatasetDiff struct {
+	Old             string             `json:"old"`
+	New             string             `json:"new"`
+	RecipesAdded    []string           `json:"recipesAdded"`
+	RecipesRemoved  []string           `json:"recipesRemoved"`
+	ElementsAdded   []string           `json:"elementsAdded"`
+	ElementsRemoved []string           `json:"elementsRemoved"`
+	TierChanges     []TierChange       `json:"tierChanges"`
+	ImageURLChanges []ImageURLChange   `json:"imageURLChanges"`
+	Summary         DatasetDiffSummary `json:"summary"`
+}
+
+type DatasetDiffRequest struct {
+	Recipes   []Recipe       `json:"recipes"`
+	ImageURLs []ElementImage `json:"imageURLs,omitempty"`
+}
+
+// loadSnapshot membaca file resep dan, jika ada, file URL gambar. File gambar yang tidak ada tidak dianggap error;
+// ImageURLs bernilai nil dan perubahan URL gambar tidak dibandingkan.
+func loadSnapshot(recipesPath, imagesPath string) (datasetSnapshot, error) {
+	recipes, err := loadRecipes(recipesPath)
+	if err != nil {
+		return datasetSnapshot{}, err
+	}
+	snapshot := datasetSnapshot{Label: recipesPath, Recipes: recipes}
+	if imagesPath != "" {
+		imageURLs, err := loadElementImageURLs(imagesPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return datasetSnapshot{}, err
+		}
+		snapshot.ImageURLs = imageURLs
+	}
+	return snapshot, nil
+}
+
+func liveSnapshot() datasetSnapshot {
+	imageURLs, _ := loadElementImageURLs(filepath.Join(diffDataDir, "element_images_urls.json"))
+	return datasetSnapshot{
+		Label:     "live:" + GetDatasetVersion(),
+		Recipes:   flattenRecipeMap(GetRecipeMap()),
+		ImageURLs: imageURLs,
+	}
+}
+
+// snapshotTiers menghitung tier elemen yang bisa dibuat; elemen yang tidak bisa dibuat tidak punya entri.
+func snapshotTiers(recipes []Recipe) map[string]int {
+	kept, _ := filterUnmakeablePaths(recipes, baseElements)
+	tiers, _ := calculateElementTiers(kept, baseElements)
+	makeable := makeableElementSet(kept, baseElements)
+	for element := range tiers {
+		if !makeable[element] {
+			delete(tiers, element)
+		}
+	}
+	return tiers
+}
+
+func snapshotElements(recipes []Recipe) map[string]bool {
+	elements := make(map[string]bool)
+	for _, base := range baseElements {
+		elements[base] = true
+	}
+	for _, recipe := range recipes {
+		elements[recipe.Result] = true
+		elements[recipe.Ingredient1] = true
+		elements[recipe.Ingredient2] = true
+	}
+	return elements
+}
+
+func sortedSetDifference(a, b map[string]bool) []string {
+	diff := []string{}
+	for key := range a {
+		if !b[key] {
+			diff = append(diff, key)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// DiffDatasets membandingkan dua snapshot. Tier -1 berarti elemen tidak bisa dibuat pada snapshot tersebut.
+func DiffDatasets(oldSnapshot, newSnapshot datasetSnapshot) DatasetDiff {
+	diff := DatasetDiff{Old: oldSnapshot.Label, New: newSnapshot.Label, TierChanges: []TierChange{}, ImageURLChanges: []ImageURLChange{}}
+
+	oldIDs, newIDs := make(map[string]bool), make(map[string]bool)
+	for _, recipe := range oldSnapshot.Recipes {
+		oldIDs[getRecipeID(recipe)] = true
+	}
+	for _, recipe := range newSnapshot.Recipes {
+		newIDs[getRecipeID(recipe)] = true
+	}
+	diff.RecipesAdded = sortedSetDifference(newIDs, oldIDs)
+	diff.RecipesRemoved = sortedSetDifference(oldIDs, newIDs)
+
+	oldElements, newElements := snapshotElements(oldSnapshot.Recipes), snapshotElements(newSnapshot.Recipes)
+	diff.ElementsAdded = sortedSetDifference(newElements, oldElements)
+	diff.ElementsRemoved = sortedSetDifference(oldElements, newElements)
+
+	oldTiers, newTiers := snapshotTiers(oldSnapshot.Recipes), snapshotTiers(newSnapshot.Recipes)
+	tierOf := func(tiers map[string]int, element string) int {
+		if tier, ok := tiers[element]; ok {
+			return tier
+		}
+		return -1
+	}
+	for element := range oldElements {
+		if !newElements[element] {
+			continue
+		}
+		before, after := tierOf(oldTiers, element), tierOf(newTiers, element)
+		if before != after {
+			diff.TierChanges = append(diff.TierChanges, TierChange{Element: element, TierBefore: before, TierAfter: after})
+		}
+	}
+	sort.Slice(diff.TierChanges, func(i, j int) bool { return diff.TierChanges[i].Element < diff.TierChanges[j].Element })
+
+	allImageElements := make(map[string]bool)
+	if oldSnapshot.ImageURLs != nil && newSnapshot.ImageURLs != nil {
+		for element := range oldSnapshot.ImageURLs {
+			allImageElements[element] = true
+		}
+		for element := range newSnapshot.ImageURLs {
+			allImageElements[element] = true
+		}
+	}
+	for element := range allImageElements {
+		before, after := oldSnapshot.ImageURLs[element], newSnapshot.ImageURLs[element]
+		if before != after {
+			diff.ImageURLChanges = append(diff.ImageURLChanges, ImageURLChange{Element: element, Before: before, After: after})
+		}
+	}
+	sort.Slice(diff.ImageURLChanges, func(i, j int) bool { return diff.ImageURLChanges[i].Element < diff.ImageURLChanges[j].Element })
+
+	diff.Summary = DatasetDiffSummary{
+		RecipesAdded:    len(diff.RecipesAdded),
+		RecipesRemoved:  len(diff.RecipesRemoved),
+		ElementsAdded:   len(diff.ElementsAdded),
+		ElementsRemoved: len(diff.ElementsRemoved),
+		TierChanges:     len(diff.TierChanges),
+		ImageURLChanges: len(diff.ImageURLChanges),
+	}
+	return diff
+}
+
+// FormatDatasetDiff menuliskan diff sebagai ringkasan yang mudah dibaca saat meninjau pembaruan data.
+func FormatDatasetDiff(diff DatasetDiff) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Diff dataset: %s -> %s\n", diff.Old, diff.New)
+	fmt.Fprintf(&sb, "Resep: +%d / -%d\n", diff.Summary.RecipesAdded, diff.Summary.RecipesRemoved)
+	fmt.Fprintf(&sb, "Elemen: +%d / -%d\n", diff.Summary.ElementsAdded, diff.Summary.ElementsRemoved)
+	fmt.Fprintf(&sb, "Perubahan tier: %d\n", diff.Summary.TierChanges)
+	fmt.Fprintf(&sb, "Perubahan URL gambar: %d\n", diff.Summary.ImageURLChanges)
+
+	writeSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "\n%s (%d):\n", title, len(lines))
+		for _, line := range lines {
+			fmt.Fprintf(&sb, "  %s\n", line)
+		}
+	}
+	prefixed := func(prefix string, values []string) []string {
+		lines := make([]string, len(values))
+		for i, value := range values {
+			lines[i] = prefix + value
+		}
+		return lines
+	}
+	writeSection("Resep ditambahkan", prefixed("+ ", diff.RecipesAdded))
+	writeSection("Resep dihapus", prefixed("- ", diff.RecipesRemoved))
+	writeSection("Elemen ditambahkan", prefixed("+ ", diff.ElementsAdded))
+	writeSection("Elemen dihapus", prefixed("- ", diff.ElementsRemoved))
+
+	tierLines := make([]string, len(diff.TierChanges))
+	for i, change := range diff.TierChanges {
+		tierLines[i] = fmt.Sprintf("%s: %s -> %s", change.Element, formatDiffTier(change.TierBefore), formatDiffTier(change.TierAfter))
+	}
+	writeSection("Perubahan tier", tierLines)
+
+	imageLines := make([]string, len(diff.ImageURLChanges))
+	for i, change := range diff.ImageURLChanges {
+		imageLines[i] = fmt.Sprintf("%s: %s -> %s", change.Element, orDash(change.Before), orDash(change.After))
+	}
+	writeSection("Perubahan URL gambar", imageLines)
+	return sb.String()
+}
+
+func formatDiffTier(tier int) string {
+	if tier < 0 {
+		return "tidak bisa dibuat"
+	}
+	return fmt.Sprintf("%d", tier)
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// resolveDataFile memetakan nama file dari API ke path di dalam folder data dan menolak path di luar folder itu.
+func resolveDataFile(name string) (string, error) {
+	cleaned := filepath.Clean(name)
+	if name == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file '%s' harus berada di dalam folder data", name)
+	}
+	return filepath.Join(diffDataDir, cleaned), nil
+}
+
+func writeDatasetDiff(w http.ResponseWriter, diff DatasetDiff, format string) {
+	if format == "text" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if _, err := w.Write([]byte(FormatDatasetDiff(diff))); err != nil {
+			log.Printf("Error saat menulis diff dataset: %v", err)
+		}
+		return
+	}
+	writeJSON(w, diff)
+}
+
+// diffHandler: GET membandingkan dua file di folder data (new kosong berarti data yang sedang dimuat);
+// POST membandingkan data yang sedang dimuat dengan resep di body.
+func diffHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	query := r.URL.Query()
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "text" {
+		http.Error(w, "Parameter 'format' harus 'json' atau 'text'", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	release, ok := acquireSearchSlot(w, r, datasetPassCost)
+	if !ok {
+		return
+	}
+	defer release()
+
+	switch r.Method {
+	case http.MethodGet:
+		oldPath, err := resolveDataFile(query.Get("old"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		oldSnapshot, err := loadSnapshot(oldPath, optionalDataFile(query.Get("oldImages")))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		newSnapshot := liveSnapshot()
+		if query.Get("new") != "" {
+			newPath, err := resolveDataFile(query.Get("new"))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if newSnapshot, err = loadSnapshot(newPath, optionalDataFile(query.Get("newImages"))); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		writeDatasetDiff(w, DiffDatasets(oldSnapshot, newSnapshot), format)
+
+	case http.MethodPost:
+		var req DatasetDiffRequest
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 16*maxBatchBodyBytes))
+		if err := decoder.Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+			return
+		}
+		if len(req.Recipes) == 0 {
+			http.Error(w, "Field 'recipes' tidak boleh kosong", http.StatusBadRequest)
+			return
+		}
+		live := liveSnapshot()
+		candidate := datasetSnapshot{Label: "request", Recipes: req.Recipes, ImageURLs: live.ImageURLs}
+		if len(req.ImageURLs) > 0 {
+			candidate.ImageURLs = make(map[string]string, len(req.ImageURLs))
+			for _, image := range req.ImageURLs {
+				candidate.ImageURLs[image.Name] = image.ImageURL
+			}
+		}
+		writeDatasetDiff(w, DiffDatasets(live, candidate), format)
+	}
+}
+
+func optionalDataFile(name string) string {
+	if name == "" {
+		return ""
+	}
+	path, err := resolveDataFile(name)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// runDiffCommand dipakai oleh flag -diff. newPath kosong berarti dataset terfilter di dataDir.
+func runDiffCommand(dataDir, oldPath, oldImages, newPath, newImages, format string) error {
+	if newPath == "" {
+		newPath = filepath.Join(dataDir, "recipes_final_filtered.json")
+	}
+	if newImages == "" {
+		newImages = filepath.Join(dataDir, "element_images_urls.json")
+	}
+	if format != "text" && format != "json" {
+		return errors.New("-diffformat harus 'text' atau 'json'")
+	}
+
+	// Log pelacakan loadRecipes dibuang agar keluaran diff tetap bersih.
+	defer silenceTrace()()
+	oldSnapshot, err := loadSnapshot(oldPath, oldImages)
+	if err != nil {
+		return err
+	}
+	newSnapshot, err := loadSnapshot(newPath, newImages)
+	if err != nil {
+		return err
+	}
+
+	diff := DiffDatasets(oldSnapshot, newSnapshot)
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+	_, err = fmt.Fprint(os.Stdout, FormatDatasetDiff(diff))
+	return err
+}
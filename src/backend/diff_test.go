@@ -0,0 +1,70 @@
+// src/backend/diff_test.go
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffDatasetsReportsChanges(t *testing.T) {
+	oldSnapshot := datasetSnapshot{
+		Label: "lama",
+		Recipes: []Recipe{
+			{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"},
+			{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"},
+			{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"},
+		},
+		ImageURLs: map[string]string{"Mud": "mud-lama.png", "Steam": "steam.png"},
+	}
+	newSnapshot := datasetSnapshot{
+		Label: "baru",
+		Recipes: []Recipe{
+			{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"},
+			{Result: "Brick", Ingredient1: "Earth", Ingredient2: "Fire"},
+			{Result: "Lava", Ingredient1: "Earth", Ingredient2: "Fire"},
+		},
+		ImageURLs: map[string]string{"Mud": "mud-baru.png", "Steam": "steam.png", "Lava": "lava.png"},
+	}
+
+	diff := DiffDatasets(oldSnapshot, newSnapshot)
+	if !reflect.DeepEqual(diff.RecipesAdded, []string{"Earth+Fire=>Brick", "Earth+Fire=>Lava"}) {
+		t.Errorf("resep ditambahkan salah: %v", diff.RecipesAdded)
+	}
+	if !reflect.DeepEqual(diff.RecipesRemoved, []string{"Fire+Mud=>Brick", "Fire+Water=>Steam"}) {
+		t.Errorf("resep dihapus salah: %v", diff.RecipesRemoved)
+	}
+	if !reflect.DeepEqual(diff.ElementsAdded, []string{"Lava"}) || !reflect.DeepEqual(diff.ElementsRemoved, []string{"Steam"}) {
+		t.Errorf("elemen ditambahkan/dihapus salah: %v %v", diff.ElementsAdded, diff.ElementsRemoved)
+	}
+	if !reflect.DeepEqual(diff.TierChanges, []TierChange{{Element: "Brick", TierBefore: 2, TierAfter: 1}}) {
+		t.Errorf("perubahan tier salah: %+v", diff.TierChanges)
+	}
+	if len(diff.ImageURLChanges) != 2 || diff.ImageURLChanges[0].Element != "Lava" || diff.ImageURLChanges[1].Before != "mud-lama.png" {
+		t.Errorf("perubahan URL gambar salah: %+v", diff.ImageURLChanges)
+	}
+
+	text := FormatDatasetDiff(diff)
+	for _, want := range []string{"Resep: +2 / -2", "Brick: 2 -> 1", "+ Lava"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("ringkasan tidak memuat %q:\n%s", want, text)
+		}
+	}
+
+	// Tanpa file gambar di salah satu sisi, URL gambar tidak dibandingkan.
+	newSnapshot.ImageURLs = nil
+	if diff := DiffDatasets(oldSnapshot, newSnapshot); len(diff.ImageURLChanges) != 0 {
+		t.Errorf("URL gambar seharusnya tidak dibandingkan: %+v", diff.ImageURLChanges)
+	}
+}
+
+func TestResolveDataFileStaysInsideDataDir(t *testing.T) {
+	for _, name := range []string{"", "../go.mod", "/etc/passwd", "versions/../../main.go"} {
+		if _, err := resolveDataFile(name); err == nil {
+			t.Errorf("path %q seharusnya ditolak", name)
+		}
+	}
+	if path, err := resolveDataFile("recipes_scraped.json"); err != nil || !strings.HasSuffix(path, "recipes_scraped.json") {
+		t.Errorf("path di dalam folder data ditolak: %q %v", path, err)
+	}
+}
@@ -0,0 +1,136 @@
+// src/backend/diversity.go
+package main
+
+import (
+	"errors"
+	"log"
+	"math"
+	"strconv"
+)
+
+// Saat minDiversity aktif, pencarian mengambil kandidat lebih banyak agar masih ada yang tersisa setelah disaring.
+const diversityOverfetchFactor = 3
+
+type PathSimilarity struct {
+	Index          int       `json:"index"`
+	MaxSimilarity  float64   `json:"maxSimilarity"`
+	MeanSimilarity float64   `json:"meanSimilarity"`
+	Similarities   []float64 `json:"similarities"`
+}
+
+func recipeKeySet(path []Recipe) map[string]bool {
+	keys := make(map[string]bool, len(path))
+	for _, recipe := range path {
+		keys[getUniqueRecipeKey(recipe)] = true
+	}
+	return keys
+}
+
+// pathSimilarity adalah indeks Jaccard atas himpunan kunci resep dua rencana; jaraknya 1 - similarity.
+func pathSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for key := range a {
+		if b[key] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+func pathDistance(a, b []Recipe) float64 {
+	return 1 - pathSimilarity(recipeKeySet(a), recipeKeySet(b))
+}
+
+// selectDiversePaths memilih secara greedy (mengikuti urutan hasil pencarian) paling banyak maxPaths rencana
+// yang jaraknya ke setiap rencana terpilih minimal minDiversity. Rencana dengan himpunan resep identik selalu dibuang.
+func selectDiversePaths(paths [][]Recipe, maxPaths int, minDiversity float64) [][]Recipe {
+	selected := make([][]Recipe, 0, min(len(paths), maxPaths))
+	selectedKeys := make([]map[string]bool, 0, cap(selected))
+	for _, path := range paths {
+		if len(selected) >= maxPaths {
+			break
+		}
+		keys := recipeKeySet(path)
+		accepted := true
+		for _, other := range selectedKeys {
+			distance := 1 - pathSimilarity(keys, other)
+			if distance <= 0 || distance < minDiversity {
+				accepted = false
+				break
+			}
+		}
+		if accepted {
+			selected = append(selected, path)
+			selectedKeys = append(selectedKeys, keys)
+		}
+	}
+	return selected
+}
+
+// annotatePathSimilarity menghitung kemiripan setiap rencana terhadap rencana lain dalam hasil yang sama.
+func annotatePathSimilarity(paths [][]Recipe) []PathSimilarity {
+	sets := make([]map[string]bool, len(paths))
+	for i, path := range paths {
+		sets[i] = recipeKeySet(path)
+	}
+
+	annotations := make([]PathSimilarity, len(paths))
+	for i := range paths {
+		annotation := PathSimilarity{Index: i, Similarities: make([]float64, len(paths))}
+		total := 0.0
+		for j := range paths {
+			if i == j {
+				annotation.Similarities[j] = 1
+				continue
+			}
+			similarity := roundSimilarity(pathSimilarity(sets[i], sets[j]))
+			annotation.Similarities[j] = similarity
+			annotation.MaxSimilarity = math.Max(annotation.MaxSimilarity, similarity)
+			total += similarity
+		}
+		if len(paths) > 1 {
+			annotation.MeanSimilarity = roundSimilarity(total / float64(len(paths)-1))
+		}
+		annotations[i] = annotation
+	}
+	return annotations
+}
+
+func roundSimilarity(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+func parseMinDiversity(raw string) (float64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || value < 0 || value > 1 {
+		return 0, errors.New("Parameter 'minDiversity' harus berupa angka antara 0 dan 1")
+	}
+	return value, nil
+}
+
+// diversityFetchCount menentukan jumlah kandidat yang diminta dari algoritma untuk mode multiple. Over-fetch
+// tetap dibatasi searchLimits.MaxRecipes supaya minDiversity tidak bisa dipakai melewati batas -maxrecipes.
+func diversityFetchCount(maxRecipes int, minDiversity float64) int {
+	if minDiversity <= 0 {
+		return maxRecipes
+	}
+	return max(maxRecipes, min(maxRecipes*diversityOverfetchFactor, searchLimits.MaxRecipes))
+}
+
+// applyDiversity menyaring kandidat mode multiple menjadi paling banyak maxRecipes rencana yang cukup berbeda
+// lalu menambahkan anotasi kemiripannya.
+func applyDiversity(response *MultiSearchResponse, maxRecipes int, minDiversity float64) {
+	candidates := len(response.Paths)
+	response.Paths = selectDiversePaths(response.Paths, maxRecipes, minDiversity)
+	response.PathSimilarity = annotatePathSimilarity(response.Paths)
+	if len(response.Paths) < min(candidates, maxRecipes) {
+		log.Printf("Diversitas: %d jalur ke %s dipilih dari %d kandidat (minDiversity=%.2f)\n", len(response.Paths), response.SearchTarget, candidates, minDiversity)
+	}
+}
@@ -0,0 +1,58 @@
+// src/backend/diversity_test.go
+package main
+
+import "testing"
+
+func TestSelectDiversePathsHonoursMinDiversity(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	candidates, _, err := FindKBestPaths("Computer", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, minDiversity := range []float64{0, 0.25, 0.5} {
+		selected := selectDiversePaths(candidates.Paths, 5, minDiversity)
+		if len(selected) == 0 || len(selected) > 5 {
+			t.Fatalf("minDiversity=%.2f: jumlah jalur terpilih %d di luar rentang", minDiversity, len(selected))
+		}
+		for i := range selected {
+			for j := i + 1; j < len(selected); j++ {
+				distance := pathDistance(selected[i], selected[j])
+				if distance <= 0 || distance < minDiversity {
+					t.Errorf("minDiversity=%.2f: jarak jalur #%d dan #%d hanya %.3f", minDiversity, i+1, j+1, distance)
+				}
+			}
+		}
+		for i, annotation := range annotatePathSimilarity(selected) {
+			if annotation.Similarities[i] != 1 || annotation.MaxSimilarity > roundSimilarity(1-minDiversity) {
+				t.Errorf("minDiversity=%.2f: anotasi jalur #%d tidak konsisten: %+v", minDiversity, i+1, annotation)
+			}
+		}
+	}
+}
+
+func TestPathDistanceIdenticalAndDisjoint(t *testing.T) {
+	mud := []Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}
+	swapped := []Recipe{{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}}
+	steam := []Recipe{{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"}}
+	if d := pathDistance(mud, swapped); d != 0 {
+		t.Errorf("urutan bahan tidak boleh memengaruhi jarak, didapat %.3f", d)
+	}
+	if d := pathDistance(mud, steam); d != 1 {
+		t.Errorf("rencana tanpa resep bersama harus berjarak 1, didapat %.3f", d)
+	}
+}
+
+func TestDiversityFetchCountRespectsMaxRecipesLimit(t *testing.T) {
+	limit := searchLimits.MaxRecipes
+	if got := diversityFetchCount(limit, 0.5); got != limit {
+		t.Errorf("over-fetch di batas maxRecipes seharusnya %d, didapat %d", limit, got)
+	}
+	if got := diversityFetchCount(2, 0.5); got != 2*diversityOverfetchFactor {
+		t.Errorf("over-fetch di bawah batas seharusnya %d, didapat %d", 2*diversityOverfetchFactor, got)
+	}
+	if got := diversityFetchCount(limit, 0); got != limit {
+		t.Errorf("tanpa minDiversity seharusnya tidak ada over-fetch, didapat %d", got)
+	}
+}
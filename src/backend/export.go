@@ -0,0 +1,533 @@
+// src/backend/export.go
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Gambar elemen yang disematkan ke SVG diambil dari folder hasil download.go.
+var exportImageDir = filepath.Join("data", "image")
+
+const (
+	svgNodeHeight = 28
+	svgLayerGap   = 80
+	svgNodeGap    = 16
+	svgCharWidth  = 7.2
+	svgPadding    = 12
+	svgImageSize  = 20
+	svgMargin     = 24
+	svgTitleSpace = 28
+
+	exportColorTarget = "#ffd166"
+	exportColorBase   = "#a0c4ff"
+	exportColorOther  = "#ffffff"
+)
+
+type exportPlan struct {
+	Title   string
+	Recipes []Recipe
+}
+
+type exportDocument struct {
+	Target string
+	Plans  []exportPlan
+}
+
+type exportRequest struct {
+	Target string
+	Format string
+	Scope  string
+	Algo   string
+	Mode   string
+	Max    int
+	Images bool
+}
+
+func normalizeExportParams(format, scope string) (string, string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	if format == "" {
+		format = "dot"
+	}
+	if scope == "" {
+		scope = "plan"
+	}
+	if format != "dot" && format != "mermaid" && format != "svg" {
+		return "", "", errors.New("Parameter 'format' harus 'dot', 'mermaid', atau 'svg'")
+	}
+	if scope != "plan" && scope != "closure" {
+		return "", "", errors.New("Parameter 'scope' harus 'plan' atau 'closure'")
+	}
+	return format, scope, nil
+}
+
+// buildExportDocument menyiapkan rencana yang akan digambar: hasil pencarian atau seluruh resep di bawah target.
+func buildExportDocument(req exportRequest) (exportDocument, error) {
+	doc := exportDocument{Target: req.Target}
+	if req.Scope == "closure" {
+		doc.Plans = []exportPlan{{Title: "Closure " + req.Target, Recipes: upstreamClosureRecipes(req.Target)}}
+		return doc, nil
+	}
+
+	response := runSearch(req.Target, req.Algo, req.Mode, req.Max, 0)
+	if !response.PathFound {
+		if response.Error != "" {
+			return doc, errors.New(response.Error)
+		}
+		return doc, fmt.Errorf("jalur ke '%s' tidak ditemukan", req.Target)
+	}
+	if req.Mode == "shortest" {
+		doc.Plans = []exportPlan{{Title: fmt.Sprintf("%s (%s)", req.Target, strings.ToUpper(req.Algo)), Recipes: response.Path}}
+		return doc, nil
+	}
+	for i, path := range response.Paths {
+		doc.Plans = append(doc.Plans, exportPlan{
+			Title:   fmt.Sprintf("%s (%s) #%d", req.Target, strings.ToUpper(req.Algo), i+1),
+			Recipes: path,
+		})
+	}
+	return doc, nil
+}
+
+// upstreamClosureRecipes mengambil semua resep yang bisa dibuat untuk target dan setiap elemen di bawahnya.
+func upstreamClosureRecipes(target string) []Recipe {
+	recipesByResult := GetRecipeMap()
+	minSizes := calculateMinTreeSizes(recipesByResult)
+	var recipes []Recipe
+	for _, element := range dependencyClosure(target, recipesByResult) {
+		if isBaseElement(element) {
+			continue
+		}
+		for _, recipe := range recipesByResult[element] {
+			_, ok1 := minSizes[recipe.Ingredient1]
+			_, ok2 := minSizes[recipe.Ingredient2]
+			if ok1 && ok2 {
+				recipes = append(recipes, recipe)
+			}
+		}
+	}
+	sort.SliceStable(recipes, func(i, j int) bool {
+		return getRecipeID(recipes[i]) < getRecipeID(recipes[j])
+	})
+	return recipes
+}
+
+func renderExport(doc exportDocument, format string, withImages bool) string {
+	switch format {
+	case "mermaid":
+		return renderMermaid(doc)
+	case "svg":
+		return renderSVG(doc, withImages)
+	}
+	return renderDOT(doc)
+}
+
+func exportContentType(format string) string {
+	switch format {
+	case "svg":
+		return "image/svg+xml"
+	case "dot":
+		return "text/vnd.graphviz; charset=utf-8"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+func exportNodeColor(element, target string) string {
+	if element == target {
+		return exportColorTarget
+	}
+	if isBaseElement(element) {
+		return exportColorBase
+	}
+	return exportColorOther
+}
+
+// planElements mengembalikan elemen-elemen sebuah rencana dalam urutan yang stabil.
+func planElements(recipes []Recipe, target string) []string {
+	seen := map[string]bool{target: true}
+	elements := []string{target}
+	for _, recipe := range recipes {
+		for _, element := range []string{recipe.Ingredient1, recipe.Ingredient2, recipe.Result} {
+			if !seen[element] {
+				seen[element] = true
+				elements = append(elements, element)
+			}
+		}
+	}
+	sort.Strings(elements)
+	return elements
+}
+
+func renderDOT(doc exportDocument) string {
+	var sb strings.Builder
+	sb.WriteString("digraph recipes {\n")
+	sb.WriteString("  rankdir=TB;\n")
+	sb.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for p, plan := range doc.Plans {
+		fmt.Fprintf(&sb, "  subgraph cluster_%d {\n", p)
+		fmt.Fprintf(&sb, "    label=%s;\n", dotQuote(plan.Title))
+		for _, element := range planElements(plan.Recipes, doc.Target) {
+			fmt.Fprintf(&sb, "    %s [label=%s, fillcolor=%s];\n",
+				dotQuote(fmt.Sprintf("p%d:%s", p, element)), dotQuote(element), dotQuote(exportNodeColor(element, doc.Target)))
+		}
+		for r, recipe := range plan.Recipes {
+			junction := dotQuote(fmt.Sprintf("p%d:r%d", p, r))
+			fmt.Fprintf(&sb, "    %s [shape=point, width=0.08, label=\"\"];\n", junction)
+			fmt.Fprintf(&sb, "    %s -> %s [arrowhead=none];\n", dotQuote(fmt.Sprintf("p%d:%s", p, recipe.Ingredient1)), junction)
+			fmt.Fprintf(&sb, "    %s -> %s [arrowhead=none];\n", dotQuote(fmt.Sprintf("p%d:%s", p, recipe.Ingredient2)), junction)
+			fmt.Fprintf(&sb, "    %s -> %s;\n", junction, dotQuote(fmt.Sprintf("p%d:%s", p, recipe.Result)))
+		}
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotQuote(value string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(value) + "\""
+}
+
+func renderMermaid(doc exportDocument) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TB\n")
+	fmt.Fprintf(&sb, "  classDef target fill:%s,stroke:#333\n", exportColorTarget)
+	fmt.Fprintf(&sb, "  classDef base fill:%s,stroke:#333\n", exportColorBase)
+	for p, plan := range doc.Plans {
+		// ID Mermaid hanya boleh alfanumerik, nama elemen dipetakan ke nomor urut.
+		ids := make(map[string]string)
+		elements := planElements(plan.Recipes, doc.Target)
+		fmt.Fprintf(&sb, "  subgraph plan%d[\"%s\"]\n", p, mermaidEscape(plan.Title))
+		for i, element := range elements {
+			ids[element] = fmt.Sprintf("p%dn%d", p, i)
+			fmt.Fprintf(&sb, "    %s[\"%s\"]\n", ids[element], mermaidEscape(element))
+		}
+		for r, recipe := range plan.Recipes {
+			junction := fmt.Sprintf("p%dr%d", p, r)
+			fmt.Fprintf(&sb, "    %s((\"+\"))\n", junction)
+			fmt.Fprintf(&sb, "    %s --- %s\n", ids[recipe.Ingredient1], junction)
+			fmt.Fprintf(&sb, "    %s --- %s\n", ids[recipe.Ingredient2], junction)
+			fmt.Fprintf(&sb, "    %s --> %s\n", junction, ids[recipe.Result])
+		}
+		sb.WriteString("  end\n")
+		for _, element := range elements {
+			if element == doc.Target {
+				fmt.Fprintf(&sb, "  class %s target\n", ids[element])
+			} else if isBaseElement(element) {
+				fmt.Fprintf(&sb, "  class %s base\n", ids[element])
+			}
+		}
+	}
+	return sb.String()
+}
+
+func mermaidEscape(value string) string {
+	return strings.ReplaceAll(value, "\"", "#quot;")
+}
+
+type svgNode struct {
+	x, y, width float64
+}
+
+type svgPanel struct {
+	title   string
+	recipes []Recipe
+	nodes   map[string]svgNode
+	width   float64
+	height  float64
+}
+
+func svgNodeWidth(element string, withImages bool) float64 {
+	width := float64(len(element))*svgCharWidth + 2*svgPadding
+	if withImages {
+		width += svgImageSize + 4
+	}
+	return width
+}
+
+// layoutSVGPanel menempatkan elemen per lapis tier (elemen dasar di atas). Urutan dalam lapis mengikuti
+// rata-rata posisi bahan pada lapis sebelumnya agar persilangan garis berkurang.
+func layoutSVGPanel(plan exportPlan, target string, withImages bool) svgPanel {
+	panel := svgPanel{title: plan.Title, recipes: plan.Recipes, nodes: make(map[string]svgNode)}
+	tiers, _ := calculateElementTiers(plan.Recipes, baseElements)
+	elements := planElements(plan.Recipes, target)
+
+	maxTier := 0
+	for _, tier := range tiers {
+		maxTier = max(maxTier, tier)
+	}
+	layers := make([][]string, maxTier+2)
+	for _, element := range elements {
+		tier, ok := tiers[element]
+		if !ok {
+			tier = maxTier + 1
+		}
+		layers[tier] = append(layers[tier], element)
+	}
+
+	ingredientsOf := make(map[string][]string)
+	for _, recipe := range plan.Recipes {
+		ingredientsOf[recipe.Result] = append(ingredientsOf[recipe.Result], recipe.Ingredient1, recipe.Ingredient2)
+	}
+
+	centers := make(map[string]float64)
+	layerWidths := make([]float64, len(layers))
+	for l, layer := range layers {
+		barycenter := make(map[string]float64, len(layer))
+		for _, element := range layer {
+			total, count := 0.0, 0
+			for _, ingredient := range ingredientsOf[element] {
+				if center, placed := centers[ingredient]; placed {
+					total += center
+					count++
+				}
+			}
+			if count > 0 {
+				barycenter[element] = total / float64(count)
+			}
+		}
+		sort.SliceStable(layer, func(i, j int) bool {
+			if barycenter[layer[i]] != barycenter[layer[j]] {
+				return barycenter[layer[i]] < barycenter[layer[j]]
+			}
+			return layer[i] < layer[j]
+		})
+
+		x := 0.0
+		for _, element := range layer {
+			width := svgNodeWidth(element, withImages)
+			centers[element] = x + width/2
+			panel.nodes[element] = svgNode{x: x, y: float64(l) * (svgNodeHeight + svgLayerGap), width: width}
+			x += width + svgNodeGap
+		}
+		layerWidths[l] = math.Max(0, x-svgNodeGap)
+		panel.width = math.Max(panel.width, layerWidths[l])
+	}
+
+	// Setiap lapis diletakkan di tengah lebar panel.
+	for l, layer := range layers {
+		offset := (panel.width - layerWidths[l]) / 2
+		for _, element := range layer {
+			node := panel.nodes[element]
+			node.x += offset
+			panel.nodes[element] = node
+		}
+	}
+
+	usedLayers := 0
+	for l, layer := range layers {
+		if len(layer) > 0 {
+			usedLayers = l + 1
+		}
+	}
+	panel.height = float64(usedLayers)*(svgNodeHeight+svgLayerGap) - svgLayerGap
+	return panel
+}
+
+func renderSVG(doc exportDocument, withImages bool) string {
+	panels := make([]svgPanel, 0, len(doc.Plans))
+	width, height := 0.0, float64(svgMargin)
+	for _, plan := range doc.Plans {
+		panel := layoutSVGPanel(plan, doc.Target, withImages)
+		panels = append(panels, panel)
+		width = math.Max(width, panel.width)
+		height += svgTitleSpace + panel.height + svgMargin
+	}
+	width += 2 * svgMargin
+
+	imageCache := make(map[string]string)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%.0f\" height=\"%.0f\" viewBox=\"0 0 %.0f %.0f\" font-family=\"Helvetica, Arial, sans-serif\" font-size=\"12\">\n",
+		width, height, width, height)
+	sb.WriteString("  <rect width=\"100%\" height=\"100%\" fill=\"#fafafa\"/>\n")
+
+	top := float64(svgMargin)
+	for _, panel := range panels {
+		left := svgMargin + (width-2*svgMargin-panel.width)/2
+		fmt.Fprintf(&sb, "  <text x=\"%.1f\" y=\"%.1f\" font-size=\"14\" font-weight=\"bold\">%s</text>\n",
+			float64(svgMargin), top+16, html.EscapeString(panel.title))
+		originY := top + svgTitleSpace
+
+		sb.WriteString("  <g stroke=\"#555\" stroke-width=\"1.2\" fill=\"none\">\n")
+		junctionsPerResult := make(map[string]int)
+		recipesPerResult := make(map[string]int)
+		for _, recipe := range panel.recipes {
+			recipesPerResult[recipe.Result]++
+		}
+		var junctions [][2]float64
+		for _, recipe := range panel.recipes {
+			result := panel.nodes[recipe.Result]
+			index := junctionsPerResult[recipe.Result]
+			junctionsPerResult[recipe.Result]++
+			spread := result.width / float64(recipesPerResult[recipe.Result]+1)
+			jx := left + result.x + spread*float64(index+1)
+			jy := originY + result.y - svgLayerGap*0.35
+			for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+				source := panel.nodes[ingredient]
+				sx := left + source.x + source.width/2
+				sy := originY + source.y + svgNodeHeight
+				fmt.Fprintf(&sb, "    <path d=\"M%.1f %.1f C%.1f %.1f %.1f %.1f %.1f %.1f\"/>\n",
+					sx, sy, sx, (sy+jy)/2, jx, (sy+jy)/2, jx, jy)
+			}
+			fmt.Fprintf(&sb, "    <path d=\"M%.1f %.1f L%.1f %.1f\" marker-end=\"url(#arrow)\"/>\n", jx, jy, jx, originY+result.y)
+			junctions = append(junctions, [2]float64{jx, jy})
+		}
+		sb.WriteString("  </g>\n")
+		for _, junction := range junctions {
+			fmt.Fprintf(&sb, "  <circle cx=\"%.1f\" cy=\"%.1f\" r=\"3\" fill=\"#555\"/>\n", junction[0], junction[1])
+		}
+
+		names := make([]string, 0, len(panel.nodes))
+		for name := range panel.nodes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			node := panel.nodes[name]
+			x, y := left+node.x, originY+node.y
+			strokeWidth := 1.0
+			if name == doc.Target {
+				strokeWidth = 2.5
+			}
+			fmt.Fprintf(&sb, "  <rect x=\"%.1f\" y=\"%.1f\" width=\"%.1f\" height=\"%d\" rx=\"6\" fill=\"%s\" stroke=\"#333\" stroke-width=\"%.1f\"/>\n",
+				x, y, node.width, svgNodeHeight, exportNodeColor(name, doc.Target), strokeWidth)
+			textX := x + svgPadding
+			if withImages {
+				if dataURI := embeddedImage(name, imageCache); dataURI != "" {
+					fmt.Fprintf(&sb, "  <image x=\"%.1f\" y=\"%.1f\" width=\"%d\" height=\"%d\" href=\"%s\"/>\n",
+						x+svgPadding/2, y+(svgNodeHeight-svgImageSize)/2, svgImageSize, svgImageSize, dataURI)
+				}
+				textX += svgImageSize + 4
+			}
+			fmt.Fprintf(&sb, "  <text x=\"%.1f\" y=\"%.1f\" dominant-baseline=\"middle\">%s</text>\n",
+				textX, y+svgNodeHeight/2, html.EscapeString(name))
+		}
+		top = originY + panel.height + svgMargin
+	}
+
+	sb.WriteString("  <defs><marker id=\"arrow\" viewBox=\"0 0 10 10\" refX=\"10\" refY=\"5\" markerWidth=\"6\" markerHeight=\"6\" orient=\"auto\"><path d=\"M0 0 L10 5 L0 10 z\" fill=\"#555\"/></marker></defs>\n")
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// embeddedImage membaca gambar elemen sebagai data URI; elemen tanpa gambar tetap digambar tanpa ikon.
+func embeddedImage(element string, cache map[string]string) string {
+	if uri, exists := cache[element]; exists {
+		return uri
+	}
+	uri := ""
+	for _, name := range []string{element, sanitizeFilename(element)} {
+		data, err := os.ReadFile(filepath.Join(exportImageDir, name+".png"))
+		if err == nil {
+			uri = "data:image/png;base64," + base64.StdEncoding.EncodeToString(data)
+			break
+		}
+	}
+	cache[element] = uri
+	return uri
+}
+
+func exportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	req := exportRequest{Target: resolveElementName(query.Get("target")), Images: query.Get("images") == "true"}
+	req.Algo, req.Mode = normalizeSearchParams(query.Get("algo"), query.Get("mode"))
+	var err error
+	if req.Format, req.Scope, err = normalizeExportParams(query.Get("format"), query.Get("scope")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateSearchParams(req.Target, req.Algo, req.Mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.Max = 1
+	if req.Mode == "multiple" {
+		req.Max, err = strconv.Atoi(query.Get("max"))
+		if err != nil || req.Max <= 0 {
+			http.Error(w, "Parameter 'max' harus berupa angka positif lebih besar dari 0 untuk mode 'multiple'", http.StatusBadRequest)
+			return
+		}
+		req.Max = limitMaxRecipes(req.Target, req.Max)
+	}
+
+	if req.Scope == "plan" {
+		release, ok := acquireSearchSlot(w, r, estimateSearchCost(req.Algo, req.Mode, req.Max, SearchOptions{}))
+		if !ok {
+			return
+		}
+		defer release()
+	}
+
+	doc, err := buildExportDocument(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", exportContentType(req.Format))
+	if _, err := w.Write([]byte(renderExport(doc, req.Format, req.Images))); err != nil {
+		log.Printf("Error saat menulis hasil ekspor: %v", err)
+	}
+}
+
+// runExportCommand dipakai oleh flag -export: memuat data, mencari, lalu menulis hasil ekspor ke file atau stdout.
+func runExportCommand(dataDir string, req exportRequest, outPath string) error {
+	// Log pemuatan data dan algoritma dibuang agar tidak bercampur dengan hasil ekspor di stdout.
+	defer silenceTrace()()
+
+	if err := InitData(dataDir); err != nil {
+		return err
+	}
+	BuildGraph(GetRecipeMap())
+
+	req.Target = resolveElementName(req.Target)
+	req.Algo, req.Mode = normalizeSearchParams(req.Algo, req.Mode)
+	var err error
+	if req.Format, req.Scope, err = normalizeExportParams(req.Format, req.Scope); err != nil {
+		return err
+	}
+	if err := validateSearchParams(req.Target, req.Algo, req.Mode); err != nil {
+		return err
+	}
+	if req.Mode == "multiple" && req.Max <= 0 {
+		return errors.New("-exportmax harus lebih besar dari 0 untuk mode 'multiple'")
+	}
+	if req.Mode == "shortest" {
+		req.Max = 1
+	}
+	exportImageDir = filepath.Join(dataDir, "image")
+
+	doc, err := buildExportDocument(req)
+	if err != nil {
+		return err
+	}
+
+	output := renderExport(doc, req.Format, req.Images)
+	if outPath == "" {
+		_, err = fmt.Fprint(os.Stdout, output)
+		return err
+	}
+	if err := os.WriteFile(outPath, []byte(output), 0644); err != nil {
+		return err
+	}
+	log.Printf("Ekspor %s untuk '%s' ditulis ke %s\n", req.Format, req.Target, outPath)
+	return nil
+}
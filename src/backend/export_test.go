@@ -0,0 +1,51 @@
+// src/backend/export_test.go
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRenderExportFormats(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, scope := range []string{"plan", "closure"} {
+		doc, err := buildExportDocument(exportRequest{Target: "Human", Scope: scope, Algo: "bfs", Mode: "multiple", Max: 2})
+		if err != nil {
+			t.Fatalf("%s: %v", scope, err)
+		}
+		recipeCount := 0
+		for _, plan := range doc.Plans {
+			recipeCount += len(plan.Recipes)
+		}
+
+		dot := renderDOT(doc)
+		if got := strings.Count(dot, "[arrowhead=none]"); got != 2*recipeCount {
+			t.Errorf("%s: DOT memiliki %d sisi bahan, diharapkan %d", scope, got, 2*recipeCount)
+		}
+		if !strings.Contains(dot, "[label=\"Human\", fillcolor=\""+exportColorTarget+"\"]") {
+			t.Errorf("%s: target tidak disorot pada DOT", scope)
+		}
+
+		mermaid := renderMermaid(doc)
+		if got := strings.Count(mermaid, " --> "); got != recipeCount {
+			t.Errorf("%s: Mermaid memiliki %d panah hasil, diharapkan %d", scope, got, recipeCount)
+		}
+
+		svg := renderSVG(doc, false)
+		decoder := xml.NewDecoder(strings.NewReader(svg))
+		for {
+			if _, err := decoder.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s: SVG tidak valid: %v", scope, err)
+			}
+		}
+		if !strings.Contains(svg, "fill=\""+exportColorBase+"\"") {
+			t.Errorf("%s: elemen dasar tidak disorot pada SVG", scope)
+		}
+	}
+}
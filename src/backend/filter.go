@@ -15,27 +15,60 @@ func getRecipeID(r Recipe) string {
 	return fmt.Sprintf("%s+%s=>%s", ings[0], ings[1], r.Result)
 }
 
-func runFilter() {
+type FilterAuditEntry struct {
+	RecipeID string `json:"recipeId"`
+	Reason   string `json:"reason"`
+}
+
+// FilterResult adalah keluaran pipeline filter: resep valid, alasan setiap resep yang dihapus, dan tier akhir.
+type FilterResult struct {
+	RawRecipes      []Recipe
+	Recipes         []Recipe
+	Audit           []FilterAuditEntry
+	Tiers           map[string]int
+	RemovedElements []string
+}
+
+func runFilter() (FilterResult, error) {
 	baseDir := "data"
 	rawRecipeFile := filepath.Join(baseDir, "recipes_scraped.json")
 	filteredRecipeFile := filepath.Join(baseDir, "recipes_final_filtered.json")
 
-	baseElements := []string{"Air", "Earth", "Fire", "Water"}
-
-	fmt.Println("Memulai skrip filter resep lanjutan...")
+	traceln("Memulai skrip filter resep lanjutan...")
 
 	rawBytes, err := os.ReadFile(rawRecipeFile)
 	if err != nil {
-		fmt.Printf("Error membaca file resep mentah '%s': %v\n", rawRecipeFile, err)
-		return
+		tracef("Error membaca file resep mentah '%s': %v\n", rawRecipeFile, err)
+		return FilterResult{}, err
 	}
 	var initialRecipes []Recipe
 	err = json.Unmarshal(rawBytes, &initialRecipes)
 	if err != nil {
-		fmt.Printf("Error unmarshal JSON resep mentah: %v\n", err)
-		return
+		tracef("Error unmarshal JSON resep mentah: %v\n", err)
+		return FilterResult{}, err
 	}
-	fmt.Printf("Berhasil memuat %d resep mentah.\n", len(initialRecipes))
+	tracef("Berhasil memuat %d resep mentah.\n", len(initialRecipes))
+
+	result := filterRecipes(initialRecipes)
+
+	filteredBytes, err := json.MarshalIndent(result.Recipes, "", "  ")
+	if err != nil {
+		tracef("Error marshal JSON resep terfilter akhir: %v\n", err)
+		return FilterResult{}, err
+	}
+	err = os.WriteFile(filteredRecipeFile, filteredBytes, 0644)
+	if err != nil {
+		tracef("Error menulis JSON resep terfilter akhir ke file '%s': %v\n", filteredRecipeFile, err)
+		return FilterResult{}, err
+	}
+
+	tracef("\nProses filter keseluruhan selesai. %d resep valid disimpan ke '%s'.\n", len(result.Recipes), filteredRecipeFile)
+	return result, nil
+}
+
+// filterRecipes menjalankan semua tahap filter pada resep mentah tanpa menyentuh file.
+func filterRecipes(initialRecipes []Recipe) FilterResult {
+	baseElements := []string{"Air", "Earth", "Fire", "Water"}
 
 	initialElementsSet := make(map[string]bool)
 	for _, base := range baseElements {
@@ -46,24 +79,24 @@ func runFilter() {
 		initialElementsSet[recipe.Ingredient1] = true
 		initialElementsSet[recipe.Ingredient2] = true
 	}
-	fmt.Printf("Jumlah elemen unik awal (termasuk dasar): %d\n", len(initialElementsSet))
+	tracef("Jumlah elemen unik awal (termasuk dasar): %d\n", len(initialElementsSet))
 
 	allRemovedRecipesTracker := make(map[string]string)
 
-	fmt.Println("\n--- TAHAP 1: Memfilter resep berdasarkan ketercapaian dari elemen dasar ---")
+	traceln("\n--- TAHAP 1: Memfilter resep berdasarkan ketercapaian dari elemen dasar ---")
 	recipesAfterStage1, removedInStage1 := filterUnmakeablePaths(initialRecipes, baseElements)
-	fmt.Printf("Tahap 1: %d resep dihapus karena tidak tercapai dari dasar. Sisa: %d resep.\n", len(removedInStage1), len(recipesAfterStage1))
+	tracef("Tahap 1: %d resep dihapus karena tidak tercapai dari dasar. Sisa: %d resep.\n", len(removedInStage1), len(recipesAfterStage1))
 	for _, r := range removedInStage1 {
 		allRemovedRecipesTracker[getRecipeID(r)] = "Tidak tercapai dari elemen dasar"
 	}
 
-	fmt.Println("\n--- TAHAP 2: Menghitung tier elemen ---")
+	traceln("\n--- TAHAP 2: Menghitung tier elemen ---")
 	elementTiersStage2, _ := calculateElementTiers(recipesAfterStage1, baseElements)
-	fmt.Printf("Tier dihitung untuk %d elemen (yang memiliki tier).\n", len(elementTiersStage2))
+	tracef("Tier dihitung untuk %d elemen (yang memiliki tier).\n", len(elementTiersStage2))
 
-	fmt.Println("\n--- TAHAP 3: Memfilter resep berdasarkan validitas tier ---")
+	traceln("\n--- TAHAP 3: Memfilter resep berdasarkan validitas tier ---")
 	recipesAfterStage3, removedInStage2 := filterByTierLogic(recipesAfterStage1, elementTiersStage2)
-	fmt.Printf("Tahap 3: %d resep dihapus karena logika tier. Sisa: %d resep.\n", len(removedInStage2), len(recipesAfterStage3))
+	tracef("Tahap 3: %d resep dihapus karena logika tier. Sisa: %d resep.\n", len(removedInStage2), len(recipesAfterStage3))
 	for _, r := range removedInStage2 {
 		tierR, _ := elementTiersStage2[r.Result]
 		tierI1, _ := elementTiersStage2[r.Ingredient1]
@@ -74,7 +107,7 @@ func runFilter() {
 		}
 	}
 
-	fmt.Println("\n--- TAHAP 4: Iterasi ulang filter ketercapaian dan tier ---")
+	traceln("\n--- TAHAP 4: Iterasi ulang filter ketercapaian dan tier ---")
 	previousRecipeCount := -1
 	currentIterationRecipes := recipesAfterStage3
 	finalIteration := 0
@@ -82,7 +115,7 @@ func runFilter() {
 
 	for len(currentIterationRecipes) != previousRecipeCount && finalIteration < maxFinalIterations {
 		finalIteration++
-		fmt.Printf("Iterasi Finalisasi Filter - Putaran %d\n", finalIteration)
+		tracef("Iterasi Finalisasi Filter - Putaran %d\n", finalIteration)
 		previousRecipeCount = len(currentIterationRecipes)
 
 		makeableInLoop, removedInUnmakeablePass := filterUnmakeablePaths(currentIterationRecipes, baseElements)
@@ -107,28 +140,28 @@ func runFilter() {
 		}
 
 		if len(currentIterationRecipes) == previousRecipeCount {
-			fmt.Println("Finalisasi filter konvergen.")
+			traceln("Finalisasi filter konvergen.")
 			break
 		}
-		fmt.Printf("  Setelah putaran %d finalisasi, tersisa %d resep.\n", finalIteration, len(currentIterationRecipes))
+		tracef("  Setelah putaran %d finalisasi, tersisa %d resep.\n", finalIteration, len(currentIterationRecipes))
 	}
 	if finalIteration >= maxFinalIterations && len(currentIterationRecipes) != previousRecipeCount {
-		fmt.Println("Peringatan: Finalisasi filter mencapai batas iterasi maksimum sebelum konvergen.")
+		traceln("Peringatan: Finalisasi filter mencapai batas iterasi maksimum sebelum konvergen.")
 	}
 	finalValidRecipes := currentIterationRecipes
 
 	if len(allRemovedRecipesTracker) > 0 {
-		fmt.Printf("\n--- Daftar Resep yang Dihapus (%d total dari semua tahap) ---\n", len(allRemovedRecipesTracker))
+		tracef("\n--- Daftar Resep yang Dihapus (%d total dari semua tahap) ---\n", len(allRemovedRecipesTracker))
 		var sortedRemovedIDs []string
 		for id := range allRemovedRecipesTracker {
 			sortedRemovedIDs = append(sortedRemovedIDs, id)
 		}
 		sort.Strings(sortedRemovedIDs)
 		for _, id := range sortedRemovedIDs {
-			fmt.Printf("  %s (Alasan: %s)\n", id, allRemovedRecipesTracker[id])
+			tracef("  %s (Alasan: %s)\n", id, allRemovedRecipesTracker[id])
 		}
 	} else {
-		fmt.Println("\nTidak ada resep yang dihapus selama proses filter.")
+		traceln("\nTidak ada resep yang dihapus selama proses filter.")
 	}
 
 	finalValidElementsSet := make(map[string]bool)
@@ -140,7 +173,7 @@ func runFilter() {
 		finalValidElementsSet[recipe.Ingredient1] = true
 		finalValidElementsSet[recipe.Ingredient2] = true
 	}
-	fmt.Printf("\nJumlah elemen unik yang valid setelah semua filter: %d\n", len(finalValidElementsSet))
+	tracef("\nJumlah elemen unik yang valid setelah semua filter: %d\n", len(finalValidElementsSet))
 
 	var removedElementsList []string
 	for initialEl := range initialElementsSet {
@@ -150,26 +183,28 @@ func runFilter() {
 	}
 	sort.Strings(removedElementsList)
 	if len(removedElementsList) > 0 {
-		fmt.Printf("\n--- Daftar Elemen yang Dihilangkan (%d total) ---\n", len(removedElementsList))
+		tracef("\n--- Daftar Elemen yang Dihilangkan (%d total) ---\n", len(removedElementsList))
 		for i, el := range removedElementsList {
-			fmt.Printf("  %d. %s\n", i+1, el)
+			tracef("  %d. %s\n", i+1, el)
 		}
 	} else {
-		fmt.Println("\nTidak ada elemen yang dihilangkan (semua elemen awal masih valid atau merupakan bagian dari resep valid).")
+		traceln("\nTidak ada elemen yang dihilangkan (semua elemen awal masih valid atau merupakan bagian dari resep valid).")
 	}
 
-	filteredBytes, err := json.MarshalIndent(finalValidRecipes, "", "  ")
-	if err != nil {
-		fmt.Printf("Error marshal JSON resep terfilter akhir: %v\n", err)
-		return
+	audit := make([]FilterAuditEntry, 0, len(allRemovedRecipesTracker))
+	for id, reason := range allRemovedRecipesTracker {
+		audit = append(audit, FilterAuditEntry{RecipeID: id, Reason: reason})
 	}
-	err = os.WriteFile(filteredRecipeFile, filteredBytes, 0644)
-	if err != nil {
-		fmt.Printf("Error menulis JSON resep terfilter akhir ke file '%s': %v\n", filteredRecipeFile, err)
-		return
+	sort.Slice(audit, func(i, j int) bool { return audit[i].RecipeID < audit[j].RecipeID })
+	finalTiers, _ := calculateElementTiers(finalValidRecipes, baseElements)
+
+	return FilterResult{
+		RawRecipes:      initialRecipes,
+		Recipes:         finalValidRecipes,
+		Audit:           audit,
+		Tiers:           finalTiers,
+		RemovedElements: removedElementsList,
 	}
-
-	fmt.Printf("\nProses filter keseluruhan selesai. %d resep valid disimpan ke '%s'.\n", len(finalValidRecipes), filteredRecipeFile)
 }
 
 func filterUnmakeablePaths(recipesToFilter []Recipe, baseElements []string) ([]Recipe, []Recipe) {
@@ -190,27 +225,7 @@ func filterUnmakeablePaths(recipesToFilter []Recipe, baseElements []string) ([]R
 		for _, base := range baseElements {
 			makeableElements[base] = true
 		}
-
-		propagationPass := 0
-		for {
-			propagationPass++
-			madeChangeThisPass := false
-			if len(currentRecipes) == 0 && propagationPass > 1 {
-				break
-			}
-
-			for _, recipe := range currentRecipes {
-				if makeableElements[recipe.Ingredient1] && makeableElements[recipe.Ingredient2] {
-					if !makeableElements[recipe.Result] {
-						makeableElements[recipe.Result] = true
-						madeChangeThisPass = true
-					}
-				}
-			}
-			if !madeChangeThisPass || propagationPass > len(recipesToFilter)+len(baseElements)+10 {
-				break
-			}
-		}
+		propagateMakeableElements(currentRecipes, makeableElements)
 
 		var nextValidRecipes []Recipe
 		for _, recipe := range currentRecipes {
@@ -224,7 +239,7 @@ func filterUnmakeablePaths(recipesToFilter []Recipe, baseElements []string) ([]R
 			break
 		}
 		if iteration > 30 {
-			fmt.Println("    Peringatan: Filter Ketercapaian melebihi batas iterasi (30).")
+			traceln("    Peringatan: Filter Ketercapaian melebihi batas iterasi (30).")
 			break
 		}
 	}
@@ -241,6 +256,28 @@ func filterUnmakeablePaths(recipesToFilter []Recipe, baseElements []string) ([]R
 	return currentRecipes, removedInThisCall
 }
 
+// propagateMakeableElements menandai hasil setiap resep yang kedua bahannya sudah ada di makeable sampai
+// tidak ada perubahan lagi. makeable diisi dengan elemen awal (elemen dasar atau inventaris pemain).
+func propagateMakeableElements(recipes []Recipe, makeable map[string]bool) {
+	startCount := len(makeable)
+	propagationPass := 0
+	for {
+		propagationPass++
+		madeChangeThisPass := false
+		for _, recipe := range recipes {
+			if makeable[recipe.Ingredient1] && makeable[recipe.Ingredient2] {
+				if !makeable[recipe.Result] {
+					makeable[recipe.Result] = true
+					madeChangeThisPass = true
+				}
+			}
+		}
+		if !madeChangeThisPass || propagationPass > len(recipes)+startCount+10 {
+			break
+		}
+	}
+}
+
 func calculateElementTiers(recipesForTierCalc []Recipe, baseElements []string) (map[string]int, map[string]bool) {
 	elementTiers := make(map[string]int)
 	allInvolvedElements := make(map[string]bool)
@@ -298,7 +335,7 @@ func calculateElementTiers(recipesForTierCalc []Recipe, baseElements []string) (
 			break
 		}
 		if iter == maxTierIterations-1 {
-			fmt.Printf("  Peringatan: Perhitungan tier mungkin mencapai batas iterasi kalkulasi (%d).\n", maxTierIterations)
+			tracef("  Peringatan: Perhitungan tier mungkin mencapai batas iterasi kalkulasi (%d).\n", maxTierIterations)
 		}
 	}
 
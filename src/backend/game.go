@@ -0,0 +1,600 @@
+// src/backend/game.go
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sesi permainan disimpan sebagai satu file JSON per sesi di data/sessions/<id>.json. Setiap perubahan
+// ditulis ulang lewat file sementara lalu di-rename, sama seperti active.json pada versi dataset.
+var gameSessionsDir = filepath.Join("data", "sessions")
+
+var gameSessionIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+// Sesi yang tidak diubah selama gameSessionTTL dianggap kedaluwarsa dan dihapus. Jumlah file sesi juga dibatasi
+// maxGameSessions; saat sesi baru dibuat, sesi yang paling lama tidak diubah dihapus lebih dulu.
+const (
+	gameSessionTTL  = 30 * 24 * time.Hour
+	maxGameSessions = 10000
+)
+
+// GameMove adalah satu kombinasi yang menemukan elemen baru. Kombinasi yang tidak menghasilkan apa pun
+// atau hanya menghasilkan elemen yang sudah dimiliki tidak dicatat, jadi undo selalu membatalkan penemuan.
+type GameMove struct {
+	A          string    `json:"a"`
+	B          string    `json:"b"`
+	Discovered []string  `json:"discovered"`
+	At         time.Time `json:"at"`
+}
+
+type GameSession struct {
+	ID                string     `json:"id"`
+	DatasetVersion    string     `json:"datasetVersion"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+	StartingInventory []string   `json:"startingInventory"`
+	Inventory         []string   `json:"inventory"`
+	Moves             []GameMove `json:"moves"`
+}
+
+type GameCombineResponse struct {
+	Session    GameSession `json:"session"`
+	Results    []string    `json:"results"`
+	Discovered []string    `json:"discovered"`
+}
+
+// GameDiscoveries adalah daftar penemuan sesi sesuai urutan ditemukan, beserta progres terhadap elemen
+// yang bisa dicapai dari inventaris awal.
+type GameDiscoveries struct {
+	ID          string   `json:"id"`
+	Discoveries []string `json:"discoveries"`
+	Owned       int      `json:"owned"`
+	Reachable   int      `json:"reachable"`
+}
+
+type gameSessionRequest struct {
+	ID        string   `json:"id"`
+	Inventory []string `json:"inventory"`
+}
+
+type gameCombineRequest struct {
+	ID string `json:"id"`
+	A  string `json:"a"`
+	B  string `json:"b"`
+}
+
+type gameLoadRequest struct {
+	Session GameSession `json:"session"`
+}
+
+// GameSessionStore membaca dan menulis sesi dari satu direktori. mu menyerialkan perubahan agar dua
+// kombinasi pada sesi yang sama tidak saling menimpa. ttl dan maxSessions bernilai nol berarti default.
+type GameSessionStore struct {
+	dir         string
+	ttl         time.Duration
+	maxSessions int
+	mu          sync.Mutex
+}
+
+var gameSessions = &GameSessionStore{dir: gameSessionsDir}
+
+func (s *GameSessionStore) sessionTTL() time.Duration {
+	if s.ttl <= 0 {
+		return gameSessionTTL
+	}
+	return s.ttl
+}
+
+func (s *GameSessionStore) sessionLimit() int {
+	if s.maxSessions <= 0 {
+		return maxGameSessions
+	}
+	return s.maxSessions
+}
+
+func newGameSessionID() (string, error) {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("gagal membuat ID sesi: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func validateGameSessionID(id string) error {
+	if !gameSessionIDPattern.MatchString(id) {
+		return fmt.Errorf("ID sesi '%s' tidak valid", id)
+	}
+	return nil
+}
+
+func (s *GameSessionStore) path(id string) string {
+	return filepath.Join(s.dir, id+".json")
+}
+
+func (s *GameSessionStore) read(id string) (GameSession, error) {
+	if err := validateGameSessionID(id); err != nil {
+		return GameSession{}, err
+	}
+	bytes, err := os.ReadFile(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		return GameSession{}, fmt.Errorf("sesi '%s' tidak ditemukan", id)
+	}
+	if err != nil {
+		return GameSession{}, err
+	}
+	var session GameSession
+	if err := json.Unmarshal(bytes, &session); err != nil {
+		return GameSession{}, fmt.Errorf("file sesi '%s' rusak: %w", id, err)
+	}
+	if time.Since(session.UpdatedAt) > s.sessionTTL() {
+		os.Remove(s.path(id))
+		return GameSession{}, fmt.Errorf("sesi '%s' sudah kedaluwarsa", id)
+	}
+	return session, nil
+}
+
+// readCurrent membaca sesi dan memastikan sesi itu berlaku untuk dataset yang sedang dimuat. Sesi dari
+// dataset lain diputar ulang seperti Load; jika masih valid, sesi diperbarui ke dataset sekarang, jika
+// tidak sesi ditolak.
+func (s *GameSessionStore) readCurrent(id string) (GameSession, error) {
+	session, err := s.read(id)
+	if err != nil {
+		return GameSession{}, err
+	}
+	version := GetDatasetVersion()
+	if session.DatasetVersion == version {
+		return session, nil
+	}
+	replayed, err := replayGameSession(session)
+	if err != nil {
+		return GameSession{}, fmt.Errorf("sesi '%s' dibuat untuk dataset %s dan tidak valid pada dataset %s: %w", id, session.DatasetVersion, version, err)
+	}
+	replayed.ID = session.ID
+	replayed.UpdatedAt = session.UpdatedAt
+	if err := s.write(replayed); err != nil {
+		return GameSession{}, err
+	}
+	return replayed, nil
+}
+
+// prune menghapus sesi yang kedaluwarsa, lalu sesi yang paling lama tidak diubah sampai masih ada tempat
+// untuk reserve sesi baru. Waktu modifikasi file dipakai karena setiap perubahan sesi menulis ulang filenya.
+func (s *GameSessionStore) prune(reserve int) error {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	type sessionFile struct {
+		path    string
+		modTime time.Time
+	}
+	var files []sessionFile
+	cutoff := time.Now().Add(-s.sessionTTL())
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(s.dir, entry.Name())
+		if info.ModTime().Before(cutoff) {
+			os.Remove(path)
+			continue
+		}
+		files = append(files, sessionFile{path: path, modTime: info.ModTime()})
+	}
+	excess := len(files) + reserve - s.sessionLimit()
+	if excess <= 0 {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, file := range files[:min(excess, len(files))] {
+		if err := os.Remove(file.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *GameSessionStore) write(session GameSession) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return fmt.Errorf("gagal membuat direktori '%s': %w", s.dir, err)
+	}
+	bytes, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := s.path(session.ID) + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, 0644); err != nil {
+		return fmt.Errorf("gagal menulis sesi '%s': %w", session.ID, err)
+	}
+	return os.Rename(tempPath, s.path(session.ID))
+}
+
+// Get mengembalikan keadaan sesi yang tersimpan; hasilnya juga bisa dipakai sebagai file simpanan untuk Load.
+func (s *GameSessionStore) Get(id string) (GameSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readCurrent(id)
+}
+
+// Create membuat sesi baru. Inventaris awal dinormalisasi seperti pada solver; elemen dasar selalu dimiliki.
+func (s *GameSessionStore) Create(rawInventory []string) (GameSession, error) {
+	inventory, err := resolveInventory(rawInventory)
+	if err != nil {
+		return GameSession{}, err
+	}
+	id, err := newGameSessionID()
+	if err != nil {
+		return GameSession{}, err
+	}
+	now := time.Now().UTC()
+	session := GameSession{
+		ID:                id,
+		DatasetVersion:    GetDatasetVersion(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		StartingInventory: inventory,
+		Inventory:         append([]string(nil), inventory...),
+		Moves:             []GameMove{},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.prune(1); err != nil {
+		return GameSession{}, err
+	}
+	if err := s.write(session); err != nil {
+		return GameSession{}, err
+	}
+	return session, nil
+}
+
+// applyGameCombination memvalidasi kombinasi a dan b terhadap inventaris lewat getRecipes. Hasil yang belum
+// dimiliki ditambahkan ke inventaris dan dikembalikan sebagai penemuan baru.
+func applyGameCombination(session *GameSession, a, b string) ([]string, []string, error) {
+	owned := make(map[string]bool, len(session.Inventory))
+	for _, element := range session.Inventory {
+		owned[element] = true
+	}
+	for _, name := range []string{a, b} {
+		if !owned[name] {
+			return nil, nil, fmt.Errorf("elemen '%s' belum dimiliki", name)
+		}
+	}
+
+	results := []string{}
+	discovered := []string{}
+	for _, recipe := range getRecipes(a, b) {
+		results = append(results, recipe.Result)
+		if !owned[recipe.Result] {
+			owned[recipe.Result] = true
+			discovered = append(discovered, recipe.Result)
+		}
+	}
+	if len(discovered) > 0 {
+		session.Inventory = append(session.Inventory, discovered...)
+		sort.Strings(session.Inventory)
+	}
+	return results, discovered, nil
+}
+
+// Combine menggabungkan dua elemen milik sesi. Nama dinormalisasi seperti /api/combine.
+func (s *GameSessionStore) Combine(id, rawA, rawB string) (GameCombineResponse, error) {
+	a, err := resolveExistingElement("a", rawA)
+	if err != nil {
+		return GameCombineResponse{}, err
+	}
+	b, err := resolveExistingElement("b", rawB)
+	if err != nil {
+		return GameCombineResponse{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.readCurrent(id)
+	if err != nil {
+		return GameCombineResponse{}, err
+	}
+	results, discovered, err := applyGameCombination(&session, a, b)
+	if err != nil {
+		return GameCombineResponse{}, err
+	}
+	if len(discovered) > 0 {
+		session.UpdatedAt = time.Now().UTC()
+		session.Moves = append(session.Moves, GameMove{A: a, B: b, Discovered: discovered, At: session.UpdatedAt})
+		if err := s.write(session); err != nil {
+			return GameCombineResponse{}, err
+		}
+	}
+	return GameCombineResponse{Session: session, Results: results, Discovered: discovered}, nil
+}
+
+// Undo membatalkan kombinasi terakhir beserta elemen yang ditemukannya.
+func (s *GameSessionStore) Undo(id string) (GameSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, err := s.readCurrent(id)
+	if err != nil {
+		return GameSession{}, err
+	}
+	if len(session.Moves) == 0 {
+		return GameSession{}, errors.New("tidak ada langkah yang bisa dibatalkan")
+	}
+	last := session.Moves[len(session.Moves)-1]
+	session.Moves = session.Moves[:len(session.Moves)-1]
+	removed := make(map[string]bool, len(last.Discovered))
+	for _, element := range last.Discovered {
+		removed[element] = true
+	}
+	inventory := session.Inventory[:0]
+	for _, element := range session.Inventory {
+		if !removed[element] {
+			inventory = append(inventory, element)
+		}
+	}
+	session.Inventory = inventory
+	session.UpdatedAt = time.Now().UTC()
+	if err := s.write(session); err != nil {
+		return GameSession{}, err
+	}
+	return session, nil
+}
+
+// Discoveries mengembalikan elemen yang ditemukan selama sesi (tanpa inventaris awal) sesuai urutan.
+func (s *GameSessionStore) Discoveries(id string) (GameDiscoveries, error) {
+	session, err := s.Get(id)
+	if err != nil {
+		return GameDiscoveries{}, err
+	}
+	response := GameDiscoveries{ID: session.ID, Discoveries: []string{}, Owned: len(session.Inventory)}
+	for _, move := range session.Moves {
+		response.Discoveries = append(response.Discoveries, move.Discovered...)
+	}
+	response.Reachable = countReachableFrom(session.StartingInventory)
+	return response, nil
+}
+
+// Jumlah elemen yang bisa dicapai hanya bergantung pada dataset dan inventaris awal, jadi disimpan per versi
+// dataset. Inventaris awal berasal dari pengguna, sehingga cache dikosongkan setelah maxGameReachableEntries.
+const maxGameReachableEntries = 1024
+
+var gameReachableCache struct {
+	sync.Mutex
+	version string
+	counts  map[string]int
+}
+
+// countReachableFrom menghitung jumlah elemen yang bisa dibuat dari inventaris awal, dari cache jika ada.
+func countReachableFrom(startingInventory []string) int {
+	dataset := getDatasetState()
+	sorted := append([]string(nil), startingInventory...)
+	sort.Strings(sorted)
+	key := strings.Join(sorted, "\x00")
+
+	gameReachableCache.Lock()
+	defer gameReachableCache.Unlock()
+	if gameReachableCache.version != dataset.version || len(gameReachableCache.counts) >= maxGameReachableEntries {
+		gameReachableCache.version = dataset.version
+		gameReachableCache.counts = make(map[string]int)
+	}
+	if count, exists := gameReachableCache.counts[key]; exists {
+		return count
+	}
+	reachable := make(map[string]bool)
+	for _, element := range startingInventory {
+		reachable[element] = true
+	}
+	propagateMakeableElements(flattenRecipeMap(dataset.recipeMap), reachable)
+	gameReachableCache.counts[key] = len(reachable)
+	return len(reachable)
+}
+
+// Load memulihkan sesi dari file simpanan sebagai sesi baru. Setiap langkah diputar ulang dari inventaris
+// awal, jadi simpanan yang diubah tangan atau tidak cocok dengan dataset sekarang ditolak.
+func (s *GameSessionStore) Load(saved GameSession) (GameSession, error) {
+	session, err := replayGameSession(saved)
+	if err != nil {
+		return GameSession{}, err
+	}
+	if session.ID, err = newGameSessionID(); err != nil {
+		return GameSession{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.prune(1); err != nil {
+		return GameSession{}, err
+	}
+	if err := s.write(session); err != nil {
+		return GameSession{}, err
+	}
+	return session, nil
+}
+
+// replayGameSession memutar ulang langkah sesi dari inventaris awal pada dataset yang sedang dimuat. ID
+// sesi tidak diisi.
+func replayGameSession(saved GameSession) (GameSession, error) {
+	inventory, err := resolveInventory(saved.StartingInventory)
+	if err != nil {
+		return GameSession{}, err
+	}
+	now := time.Now().UTC()
+	session := GameSession{
+		DatasetVersion:    GetDatasetVersion(),
+		CreatedAt:         saved.CreatedAt,
+		UpdatedAt:         now,
+		StartingInventory: inventory,
+		Inventory:         append([]string(nil), inventory...),
+		Moves:             []GameMove{},
+	}
+	if session.CreatedAt.IsZero() {
+		session.CreatedAt = now
+	}
+	for i, move := range saved.Moves {
+		_, discovered, err := applyGameCombination(&session, move.A, move.B)
+		if err != nil {
+			return GameSession{}, fmt.Errorf("langkah %d (%s + %s) tidak valid: %w", i+1, move.A, move.B, err)
+		}
+		if len(discovered) == 0 {
+			return GameSession{}, fmt.Errorf("langkah %d (%s + %s) tidak menemukan elemen baru", i+1, move.A, move.B)
+		}
+		session.Moves = append(session.Moves, GameMove{A: move.A, B: move.B, Discovered: discovered, At: move.At})
+	}
+	return session, nil
+}
+
+// sessionInventory mengembalikan inventaris sesi untuk endpoint solver yang menerima parameter session.
+func sessionInventory(id string) ([]string, error) {
+	session, err := gameSessions.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return session.Inventory, nil
+}
+
+func setGameHeaders(w http.ResponseWriter, methods string) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", methods)
+}
+
+func decodeGameRequest(w http.ResponseWriter, r *http.Request, request any) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	if err := decoder.Decode(request); err != nil {
+		http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// gameSessionsHandler melayani /api/game/sessions: POST {"inventory": [...]} membuat sesi baru, GET ?id=
+// mengembalikan keadaan sesi (sekaligus file simpanan untuk /api/game/load).
+func gameSessionsHandler(w http.ResponseWriter, r *http.Request) {
+	setGameHeaders(w, "GET, POST, OPTIONS")
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		session, err := gameSessions.Get(r.URL.Query().Get("id"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		writeJSON(w, session)
+	case http.MethodPost:
+		var request gameSessionRequest
+		if r.ContentLength != 0 && !decodeGameRequest(w, r, &request) {
+			return
+		}
+		session, err := gameSessions.Create(request.Inventory)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, session)
+	default:
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+	}
+}
+
+// gameCombineHandler melayani POST /api/game/combine dengan body {"id": ..., "a": ..., "b": ...}.
+func gameCombineHandler(w http.ResponseWriter, r *http.Request) {
+	setGameHeaders(w, "POST, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	var request gameCombineRequest
+	if !decodeGameRequest(w, r, &request) {
+		return
+	}
+	response, err := gameSessions.Combine(request.ID, request.A, request.B)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, response)
+}
+
+// gameUndoHandler melayani POST /api/game/undo dengan body {"id": ...}.
+func gameUndoHandler(w http.ResponseWriter, r *http.Request) {
+	setGameHeaders(w, "POST, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	var request gameSessionRequest
+	if !decodeGameRequest(w, r, &request) {
+		return
+	}
+	session, err := gameSessions.Undo(request.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, session)
+}
+
+// gameDiscoveriesHandler melayani GET /api/game/discoveries?id=.
+func gameDiscoveriesHandler(w http.ResponseWriter, r *http.Request) {
+	setGameHeaders(w, "GET")
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	discoveries, err := gameSessions.Discoveries(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	writeJSON(w, discoveries)
+}
+
+// gameLoadHandler melayani POST /api/game/load dengan body {"session": <hasil GET /api/game/sessions>}.
+func gameLoadHandler(w http.ResponseWriter, r *http.Request) {
+	setGameHeaders(w, "POST, OPTIONS")
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	var request gameLoadRequest
+	if !decodeGameRequest(w, r, &request) {
+		return
+	}
+	session, err := gameSessions.Load(request.Session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, session)
+}
@@ -0,0 +1,175 @@
+// src/backend/game_test.go
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGameSessionCombineUndoAndPersist(t *testing.T) {
+	loadTestDataset(t)
+	store := &GameSessionStore{dir: t.TempDir()}
+
+	session, err := store.Create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(session.Inventory, []string{"Air", "Earth", "Fire", "Water"}) {
+		t.Fatalf("inventaris awal salah: %v", session.Inventory)
+	}
+
+	combined, err := store.Combine(session.ID, "water", "EARTH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(combined.Discovered, []string{"Mud"}) || len(combined.Session.Moves) != 1 {
+		t.Fatalf("Water + Earth seharusnya menemukan Mud: %+v", combined)
+	}
+	again, err := store.Combine(session.ID, "Earth", "Water")
+	if err != nil || len(again.Discovered) != 0 || !reflect.DeepEqual(again.Results, []string{"Mud"}) || len(again.Session.Moves) != 1 {
+		t.Errorf("kombinasi ulang tidak boleh dicatat sebagai langkah: %+v %v", again, err)
+	}
+	if _, err := store.Combine(session.ID, "Mud", "Steam"); err == nil {
+		t.Error("elemen yang belum dimiliki seharusnya ditolak")
+	}
+	if _, err := store.Combine(session.ID, "Fire", "Water"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Store baru pada direktori yang sama harus membaca keadaan yang sama dari file.
+	reopened := &GameSessionStore{dir: store.dir}
+	persisted, err := reopened.Get(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(persisted.Inventory, []string{"Air", "Earth", "Fire", "Mud", "Steam", "Water"}) {
+		t.Errorf("inventaris tersimpan salah: %v", persisted.Inventory)
+	}
+	discoveries, err := reopened.Discoveries(session.ID)
+	if err != nil || !reflect.DeepEqual(discoveries.Discoveries, []string{"Mud", "Steam"}) || discoveries.Reachable != len(GetAllElementNames()) {
+		t.Errorf("daftar penemuan salah: %+v %v", discoveries, err)
+	}
+
+	undone, err := reopened.Undo(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(undone.Inventory, []string{"Air", "Earth", "Fire", "Mud", "Water"}) || len(undone.Moves) != 1 {
+		t.Errorf("undo seharusnya hanya menghapus Steam: %+v", undone)
+	}
+	if _, err := reopened.Undo(session.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.Undo(session.ID); err == nil {
+		t.Error("undo tanpa langkah seharusnya ditolak")
+	}
+	if _, err := reopened.Get("../../etc/passwd"); err == nil {
+		t.Error("ID sesi yang tidak valid seharusnya ditolak")
+	}
+}
+
+func TestGameSessionLoadReplaysMoves(t *testing.T) {
+	loadTestDataset(t)
+	store := &GameSessionStore{dir: t.TempDir()}
+
+	session, err := store.Create([]string{"mud"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Combine(session.ID, "Fire", "Mud"); err != nil {
+		t.Fatal(err)
+	}
+	saved, err := store.Get(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ID == saved.ID || !reflect.DeepEqual(loaded.Inventory, saved.Inventory) || !reflect.DeepEqual(loaded.Moves, saved.Moves) {
+		t.Errorf("sesi yang dimuat tidak sama dengan simpanan: %+v vs %+v", loaded, saved)
+	}
+
+	tampered := saved
+	tampered.Moves = append([]GameMove{{A: "Brick", B: "Fire"}}, saved.Moves...)
+	if _, err := store.Load(tampered); err == nil {
+		t.Error("simpanan dengan langkah yang tidak valid seharusnya ditolak")
+	}
+}
+
+func TestGameSessionRevalidatesOtherDatasetVersion(t *testing.T) {
+	loadTestDataset(t)
+	store := &GameSessionStore{dir: t.TempDir()}
+
+	session, err := store.Create(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combined, err := store.Combine(session.ID, "Water", "Earth")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sesi dari dataset lain yang langkahnya masih valid diperbarui ke dataset sekarang.
+	stale := combined.Session
+	stale.DatasetVersion = "0000000000000000"
+	if err := store.write(stale); err != nil {
+		t.Fatal(err)
+	}
+	undone, err := store.Undo(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if undone.DatasetVersion != GetDatasetVersion() || len(undone.Moves) != 0 {
+		t.Errorf("sesi seharusnya divalidasi ulang lalu di-undo: %+v", undone)
+	}
+
+	// Sesi dari dataset lain yang langkahnya tidak valid lagi ditolak.
+	stale.Moves = []GameMove{{A: "Brick", B: "Fire", Discovered: []string{"Kiln"}}}
+	if err := store.write(stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Combine(session.ID, "Fire", "Water"); err == nil {
+		t.Error("sesi yang tidak valid pada dataset sekarang seharusnya ditolak")
+	}
+}
+
+func TestGameSessionExpiryAndLimit(t *testing.T) {
+	loadTestDataset(t)
+	store := &GameSessionStore{dir: t.TempDir(), ttl: time.Hour, maxSessions: 2}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		session, err := store.Create(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		old := time.Now().Add(time.Duration(i-3) * time.Minute)
+		if err := os.Chtimes(store.path(session.ID), old, old); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, session.ID)
+	}
+	if _, err := store.Get(ids[0]); err == nil {
+		t.Error("sesi tertua seharusnya dihapus saat batas jumlah sesi tercapai")
+	}
+	if _, err := store.Get(ids[2]); err != nil {
+		t.Errorf("sesi terbaru seharusnya masih ada: %v", err)
+	}
+
+	expired, err := store.Get(ids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired.UpdatedAt = time.Now().Add(-2 * time.Hour)
+	if err := store.write(expired); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(expired.ID); err == nil {
+		t.Error("sesi yang kedaluwarsa seharusnya ditolak")
+	}
+}
@@ -1,32 +1,49 @@
 // src/backend/graph.go
 package main
 
-import (
-	"fmt"
-	"sync"
-)
+import "sync"
 
 var (
 	alchemyGraph map[string][]Recipe
+	// compactGraph adalah representasi ID integer dari dataset yang sama, dipakai BFS, DFS, dan BDS.
+	compactGraph *CompactGraph
 
 	buildGraphOnce sync.Once
 )
 
 func BuildGraph(inputRecipeMap map[string][]Recipe) {
 	buildGraphOnce.Do(func() { // Hanya jalankan sekali
-		fmt.Println("Membangun struktur graf dari data resep...")
-		alchemyGraph = make(map[string][]Recipe)
-
-		for _, recipes := range inputRecipeMap {
-			for _, recipe := range recipes {
-				alchemyGraph[recipe.Ingredient1] = append(alchemyGraph[recipe.Ingredient1], recipe)
-				alchemyGraph[recipe.Ingredient2] = append(alchemyGraph[recipe.Ingredient2], recipe)
-			}
-		}
-		fmt.Printf("Graf selesai dibangun. Jumlah node (elemen bahan) dalam graf: %d\n", len(alchemyGraph))
+		graph := buildAlchemyGraph(inputRecipeMap)
+		compact := buildCompactGraph(inputRecipeMap)
+		datasetMu.Lock()
+		alchemyGraph = graph
+		compactGraph = compact
+		datasetMu.Unlock()
 	})
 }
 
+func buildAlchemyGraph(inputRecipeMap map[string][]Recipe) map[string][]Recipe {
+	traceln("Membangun struktur graf dari data resep...")
+	graph := make(map[string][]Recipe)
+
+	for _, recipes := range inputRecipeMap {
+		for _, recipe := range recipes {
+			graph[recipe.Ingredient1] = append(graph[recipe.Ingredient1], recipe)
+			graph[recipe.Ingredient2] = append(graph[recipe.Ingredient2], recipe)
+		}
+	}
+	tracef("Graf selesai dibangun. Jumlah node (elemen bahan) dalam graf: %d\n", len(graph))
+	return graph
+}
+
 func GetAlchemyGraph() map[string][]Recipe {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
 	return alchemyGraph
 }
+
+func GetCompactGraph() *CompactGraph {
+	datasetMu.RLock()
+	defer datasetMu.RUnlock()
+	return compactGraph
+}
@@ -0,0 +1,305 @@
+// src/backend/graphexport.go
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Graf resep sebagai hipergraf: simpul elemen dan simpul resep. Setiap resep punya sisi dari kedua bahannya
+// dan satu sisi ke hasilnya, sehingga bisa dibuka langsung di Gephi atau NetworkX.
+type graphExportNode struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Label     string `json:"label"`
+	Tier      int    `json:"tier"`
+	ImageURL  string `json:"imageURL,omitempty"`
+	InDegree  int    `json:"inDegree"`
+	OutDegree int    `json:"outDegree"`
+}
+
+type graphExportEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Role   string `json:"role"`
+}
+
+type graphExportModel struct {
+	Nodes []graphExportNode
+	Edges []graphExportEdge
+}
+
+var graphExportFormats = map[string]struct {
+	contentType string
+	extension   string
+}{
+	"graphml": {"application/graphml+xml", ".graphml"},
+	"gexf":    {"application/gexf+xml", ".gexf"},
+	"json":    {"application/json", ".json"},
+}
+
+func normalizeGraphExportFormat(raw string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "" {
+		format = "graphml"
+	}
+	if _, ok := graphExportFormats[format]; !ok {
+		return "", errors.New("Parameter 'format' harus 'graphml', 'gexf', atau 'json'")
+	}
+	return format, nil
+}
+
+func elementNodeID(element string) string { return "e:" + element }
+func recipeNodeID(recipe Recipe) string   { return "r:" + getRecipeID(recipe) }
+
+// buildGraphExportModel menyusun simpul dan sisi secara deterministik. Elemen yang tidak bisa dibuat bertier -1;
+// tier simpul resep adalah tier paling awal resep itu bisa dipakai.
+func buildGraphExportModel(recipes []Recipe, elementNames map[string]bool, imageURLs map[string]string) graphExportModel {
+	tiers, _ := calculateElementTiers(recipes, baseElements)
+	tierOf := func(element string) int {
+		if tier, ok := tiers[element]; ok {
+			return tier
+		}
+		return -1
+	}
+
+	sortedRecipes := append([]Recipe(nil), recipes...)
+	sort.SliceStable(sortedRecipes, func(i, j int) bool {
+		return getRecipeID(sortedRecipes[i]) < getRecipeID(sortedRecipes[j])
+	})
+
+	produced := make(map[string]int)
+	used := make(map[string]int)
+	for _, recipe := range sortedRecipes {
+		produced[recipe.Result]++
+		used[recipe.Ingredient1]++
+		if recipe.Ingredient2 != recipe.Ingredient1 {
+			used[recipe.Ingredient2]++
+		}
+	}
+
+	names := make([]string, 0, len(elementNames))
+	for name := range elementNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	model := graphExportModel{}
+	for _, name := range names {
+		model.Nodes = append(model.Nodes, graphExportNode{
+			ID:        elementNodeID(name),
+			Kind:      "element",
+			Label:     name,
+			Tier:      tierOf(name),
+			ImageURL:  imageURLs[name],
+			InDegree:  produced[name],
+			OutDegree: used[name],
+		})
+	}
+	for _, recipe := range sortedRecipes {
+		recipeTier := -1
+		if tierOf(recipe.Ingredient1) >= 0 && tierOf(recipe.Ingredient2) >= 0 {
+			recipeTier = 1 + max(tierOf(recipe.Ingredient1), tierOf(recipe.Ingredient2))
+		}
+		id := recipeNodeID(recipe)
+		model.Nodes = append(model.Nodes, graphExportNode{
+			ID:        id,
+			Kind:      "recipe",
+			Label:     recipe.Ingredient1 + " + " + recipe.Ingredient2,
+			Tier:      recipeTier,
+			InDegree:  2,
+			OutDegree: 1,
+		})
+		model.Edges = append(model.Edges,
+			graphExportEdge{Source: elementNodeID(recipe.Ingredient1), Target: id, Role: "ingredient"},
+			graphExportEdge{Source: elementNodeID(recipe.Ingredient2), Target: id, Role: "ingredient"},
+			graphExportEdge{Source: id, Target: elementNodeID(recipe.Result), Role: "result"},
+		)
+	}
+	return model
+}
+
+func writeGraphExport(w io.Writer, model graphExportModel, format string) error {
+	buffered := bufio.NewWriter(w)
+	var err error
+	switch format {
+	case "gexf":
+		err = writeGEXF(buffered, model)
+	case "json":
+		err = writeNodeLinkJSON(buffered, model)
+	default:
+		err = writeGraphML(buffered, model)
+	}
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+func xmlEscape(value string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
+
+func writeGraphML(w *bufio.Writer, model graphExportModel) error {
+	w.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	w.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	w.WriteString("  <key id=\"kind\" for=\"node\" attr.name=\"kind\" attr.type=\"string\"/>\n")
+	w.WriteString("  <key id=\"label\" for=\"node\" attr.name=\"label\" attr.type=\"string\"/>\n")
+	w.WriteString("  <key id=\"tier\" for=\"node\" attr.name=\"tier\" attr.type=\"int\"/>\n")
+	w.WriteString("  <key id=\"imageURL\" for=\"node\" attr.name=\"imageURL\" attr.type=\"string\"/>\n")
+	w.WriteString("  <key id=\"inDegree\" for=\"node\" attr.name=\"inDegree\" attr.type=\"int\"/>\n")
+	w.WriteString("  <key id=\"outDegree\" for=\"node\" attr.name=\"outDegree\" attr.type=\"int\"/>\n")
+	w.WriteString("  <key id=\"role\" for=\"edge\" attr.name=\"role\" attr.type=\"string\"/>\n")
+	w.WriteString("  <graph id=\"recipes\" edgedefault=\"directed\">\n")
+	for _, node := range model.Nodes {
+		fmt.Fprintf(w, "    <node id=\"%s\"><data key=\"kind\">%s</data><data key=\"label\">%s</data><data key=\"tier\">%d</data>",
+			xmlEscape(node.ID), node.Kind, xmlEscape(node.Label), node.Tier)
+		if node.ImageURL != "" {
+			fmt.Fprintf(w, "<data key=\"imageURL\">%s</data>", xmlEscape(node.ImageURL))
+		}
+		fmt.Fprintf(w, "<data key=\"inDegree\">%d</data><data key=\"outDegree\">%d</data></node>\n", node.InDegree, node.OutDegree)
+	}
+	for i, edge := range model.Edges {
+		fmt.Fprintf(w, "    <edge id=\"x%d\" source=\"%s\" target=\"%s\"><data key=\"role\">%s</data></edge>\n",
+			i, xmlEscape(edge.Source), xmlEscape(edge.Target), edge.Role)
+	}
+	w.WriteString("  </graph>\n")
+	_, err := w.WriteString("</graphml>\n")
+	return err
+}
+
+func writeGEXF(w *bufio.Writer, model graphExportModel) error {
+	w.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	w.WriteString("<gexf xmlns=\"http://gexf.net/1.3\" version=\"1.3\">\n")
+	w.WriteString("  <graph mode=\"static\" defaultedgetype=\"directed\">\n")
+	w.WriteString("    <attributes class=\"node\">\n")
+	w.WriteString("      <attribute id=\"0\" title=\"kind\" type=\"string\"/>\n")
+	w.WriteString("      <attribute id=\"1\" title=\"tier\" type=\"integer\"/>\n")
+	w.WriteString("      <attribute id=\"2\" title=\"imageURL\" type=\"string\"/>\n")
+	w.WriteString("      <attribute id=\"3\" title=\"inDegree\" type=\"integer\"/>\n")
+	w.WriteString("      <attribute id=\"4\" title=\"outDegree\" type=\"integer\"/>\n")
+	w.WriteString("    </attributes>\n")
+	w.WriteString("    <attributes class=\"edge\">\n")
+	w.WriteString("      <attribute id=\"0\" title=\"role\" type=\"string\"/>\n")
+	w.WriteString("    </attributes>\n")
+	w.WriteString("    <nodes>\n")
+	for _, node := range model.Nodes {
+		fmt.Fprintf(w, "      <node id=\"%s\" label=\"%s\"><attvalues><attvalue for=\"0\" value=\"%s\"/><attvalue for=\"1\" value=\"%d\"/>",
+			xmlEscape(node.ID), xmlEscape(node.Label), node.Kind, node.Tier)
+		if node.ImageURL != "" {
+			fmt.Fprintf(w, "<attvalue for=\"2\" value=\"%s\"/>", xmlEscape(node.ImageURL))
+		}
+		fmt.Fprintf(w, "<attvalue for=\"3\" value=\"%d\"/><attvalue for=\"4\" value=\"%d\"/></attvalues></node>\n", node.InDegree, node.OutDegree)
+	}
+	w.WriteString("    </nodes>\n")
+	w.WriteString("    <edges>\n")
+	for i, edge := range model.Edges {
+		fmt.Fprintf(w, "      <edge id=\"%d\" source=\"%s\" target=\"%s\"><attvalues><attvalue for=\"0\" value=\"%s\"/></attvalues></edge>\n",
+			i, xmlEscape(edge.Source), xmlEscape(edge.Target), edge.Role)
+	}
+	w.WriteString("    </edges>\n")
+	w.WriteString("  </graph>\n")
+	_, err := w.WriteString("</gexf>\n")
+	return err
+}
+
+// writeNodeLinkJSON mengikuti format node-link NetworkX (networkx.node_link_graph), ditulis simpul demi simpul.
+// multigraph bernilai true karena resep dengan dua bahan sama memiliki dua sisi paralel.
+func writeNodeLinkJSON(w *bufio.Writer, model graphExportModel) error {
+	w.WriteString("{\"directed\": true, \"multigraph\": true, \"graph\": {\"name\": \"recipes\"},\n\"nodes\": [\n")
+	for i, node := range model.Nodes {
+		encoded, err := json.Marshal(node)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			w.WriteString(",\n")
+		}
+		w.Write(encoded)
+	}
+	w.WriteString("\n],\n\"links\": [\n")
+	for i, edge := range model.Edges {
+		encoded, err := json.Marshal(edge)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			w.WriteString(",\n")
+		}
+		w.Write(encoded)
+	}
+	_, err := w.WriteString("\n]}\n")
+	return err
+}
+
+// currentGraphExportModel memakai data yang sedang dimuat; URL gambar bersifat opsional.
+func currentGraphExportModel(imageURLsPath string) graphExportModel {
+	imageURLs, err := loadElementImageURLs(imageURLsPath)
+	if err != nil {
+		log.Printf("Peringatan: URL gambar tidak disertakan dalam ekspor graf: %v\n", err)
+		imageURLs = map[string]string{}
+	}
+	return buildGraphExportModel(flattenRecipeMap(GetRecipeMap()), GetAllElementNames(), imageURLs)
+}
+
+func graphExportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format, err := normalizeGraphExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	model := currentGraphExportModel(jsonFilePath)
+	w.Header().Set("Content-Type", graphExportFormats[format].contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"recipes%s\"", graphExportFormats[format].extension))
+	if err := writeGraphExport(w, model, format); err != nil {
+		log.Printf("Error saat menulis ekspor graf: %v", err)
+	}
+}
+
+// runGraphExportCommand dipakai oleh flag -exportgraph. Format diambil dari ekstensi file jika tidak diberikan.
+func runGraphExportCommand(dataDir, outPath, format string) error {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(outPath)), ".")
+	}
+	format, err := normalizeGraphExportFormat(format)
+	if err != nil {
+		return err
+	}
+	if err := InitData(dataDir); err != nil {
+		return err
+	}
+
+	file, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	model := currentGraphExportModel(filepath.Join(dataDir, "element_images_urls.json"))
+	if err := writeGraphExport(file, model, format); err != nil {
+		return err
+	}
+	log.Printf("Graf resep (%d simpul, %d sisi) ditulis ke %s dalam format %s\n", len(model.Nodes), len(model.Edges), outPath, format)
+	return file.Close()
+}
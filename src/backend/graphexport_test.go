@@ -0,0 +1,67 @@
+// src/backend/graphexport_test.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+func TestGraphExportFormatsRoundTrip(t *testing.T) {
+	loadTestDataset(t)
+
+	recipes := flattenRecipeMap(GetRecipeMap())
+	model := buildGraphExportModel(recipes, GetAllElementNames(), map[string]string{"Fire": "https://example.com/fire.svg?a=1&b=2"})
+	if want := len(GetAllElementNames()) + len(recipes); len(model.Nodes) != want {
+		t.Fatalf("jumlah simpul %d, diharapkan %d", len(model.Nodes), want)
+	}
+	if len(model.Edges) != 3*len(recipes) {
+		t.Fatalf("jumlah sisi %d, diharapkan %d", len(model.Edges), 3*len(recipes))
+	}
+
+	for _, format := range []string{"graphml", "gexf"} {
+		var buf bytes.Buffer
+		if err := writeGraphExport(&buf, model, format); err != nil {
+			t.Fatal(err)
+		}
+		decoder := xml.NewDecoder(&buf)
+		nodes, edges := 0, 0
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s tidak valid: %v", format, err)
+			}
+			if start, ok := token.(xml.StartElement); ok {
+				switch start.Name.Local {
+				case "node":
+					nodes++
+				case "edge":
+					edges++
+				}
+			}
+		}
+		if nodes != len(model.Nodes) || edges != len(model.Edges) {
+			t.Errorf("%s: %d simpul dan %d sisi, diharapkan %d dan %d", format, nodes, edges, len(model.Nodes), len(model.Edges))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeGraphExport(&buf, model, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Nodes []graphExportNode `json:"nodes"`
+		Links []graphExportEdge `json:"links"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON node-link tidak valid: %v", err)
+	}
+	if len(decoded.Nodes) != len(model.Nodes) || len(decoded.Links) != len(model.Edges) {
+		t.Errorf("JSON: %d simpul dan %d sisi", len(decoded.Nodes), len(decoded.Links))
+	}
+}
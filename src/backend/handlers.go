@@ -3,6 +3,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -17,8 +18,16 @@ type MultiSearchResponse struct {
 	Mode           string            `json:"mode"`
 	MaxRecipes     int               `json:"maxRecipes,omitempty"`
 	PathFound      bool              `json:"pathFound"`
+	Exhausted      bool              `json:"exhausted,omitempty"`
+	Truncated      bool              `json:"truncated,omitempty"`
+	MinDiversity   float64           `json:"minDiversity,omitempty"`
 	Path           []Recipe          `json:"path,omitempty"`
 	Paths          [][]Recipe        `json:"paths,omitempty"`
+	PathSimilarity []PathSimilarity  `json:"pathSimilarity,omitempty"`
+	Tree           *RecipeTree       `json:"tree,omitempty"`
+	Trees          []RecipeTree      `json:"trees,omitempty"`
+	Iterations     []SearchIteration `json:"iterations,omitempty"`
+	Beam           *BeamReport       `json:"beam,omitempty"`
 	ImageURLs      map[string]string `json:"imageURLs,omitempty"`
 	NodesVisited   int               `json:"nodesVisited"`
 	DurationMillis int64             `json:"durationMillis"`
@@ -34,7 +43,66 @@ func searchHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	targetElement := strings.TrimSpace(r.URL.Query().Get("target"))
+	targetElement := resolveElementName(r.URL.Query().Get("target"))
+	algo, mode := normalizeSearchParams(r.URL.Query().Get("algo"), r.URL.Query().Get("mode"))
+	maxRecipesStr := r.URL.Query().Get("max")
+
+	if err := validateSearchParams(targetElement, algo, mode); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	maxRecipes := 1
+	if mode == "multiple" {
+		if maxRecipesStr != "" {
+			var convErr error
+			maxRecipes, convErr = strconv.Atoi(maxRecipesStr)
+			if convErr != nil || maxRecipes <= 0 {
+				http.Error(w, "Parameter 'max' harus berupa angka positif lebih besar dari 0 untuk mode 'multiple'", http.StatusBadRequest)
+				return
+			}
+		} else {
+			http.Error(w, "Parameter 'max' diperlukan untuk mode 'multiple'", http.StatusBadRequest)
+			return
+		}
+		maxRecipes = limitMaxRecipes(targetElement, maxRecipes)
+	}
+
+	minDiversity, err := parseMinDiversity(r.URL.Query().Get("minDiversity"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, err := normalizeResponseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	options, err := parseSearchOptions(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	release, ok := acquireSearchSlot(w, r, estimateSearchCost(algo, mode, diversityFetchCount(maxRecipes, minDiversity), options))
+	if !ok {
+		return
+	}
+	defer release()
+
+	response := runSearchWithOptions(targetElement, algo, mode, maxRecipes, minDiversity, options)
+	if format == "tree" {
+		if err := attachRecipeTrees(&response); err != nil {
+			log.Printf("Gagal membangun pohon resep untuk %s: %v\n", targetElement, err)
+			response.Error = err.Error()
+		}
+	}
+	writeJSON(w, response)
+}
+
+// resolveElementName mencocokkan input pengguna dengan nama elemen yang ada tanpa memedulikan kapitalisasi.
+func resolveElementName(raw string) string {
+	targetElement := strings.TrimSpace(raw)
 	titleCaseTarget := toTitleCase(targetElement)
 	firstCapTarget := ""
 	if len(targetElement) > 0 {
@@ -46,64 +114,69 @@ func searchHandler(w http.ResponseWriter, r *http.Request) {
 	lowerCaseTarget := strings.ToLower(targetElement)
 	upperCaseTarget := strings.ToUpper(targetElement)
 	potentialTargets := []string{titleCaseTarget, firstCapTarget, targetElement, lowerCaseTarget, upperCaseTarget}
-	validTarget := ""
 	for _, potTarget := range potentialTargets {
 		if IsElementExists(potTarget) {
-			validTarget = potTarget
-			break
+			return potTarget
 		}
 	}
-	if validTarget == "" {
-		validTarget = titleCaseTarget
-	}
-	targetElement = validTarget
-
-	algo := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("algo")))
-	mode := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("mode")))
-	maxRecipesStr := r.URL.Query().Get("max")
+	return titleCaseTarget
+}
 
+func normalizeSearchParams(algo, mode string) (string, string) {
+	algo = strings.ToLower(strings.TrimSpace(algo))
+	mode = strings.ToLower(strings.TrimSpace(mode))
 	if algo == "" {
 		algo = "bfs"
 	}
 	if mode == "" {
 		mode = "shortest"
 	}
+	return algo, mode
+}
 
+func validateSearchParams(targetElement, algo, mode string) error {
 	if targetElement == "" {
-		http.Error(w, "Parameter 'target' diperlukan", http.StatusBadRequest)
-		return
+		return errors.New("Parameter 'target' diperlukan")
 	}
 	if !IsElementExists(targetElement) {
-		http.Error(w, fmt.Sprintf("Elemen target '%s' tidak valid atau tidak ditemukan", targetElement), http.StatusBadRequest)
-		return
+		return fmt.Errorf("Elemen target '%s' tidak valid atau tidak ditemukan", targetElement)
 	}
-	if algo != "bfs" && algo != "dfs" && algo != "bds" {
-		http.Error(w, "Parameter 'algo' harus 'bfs', 'dfs', atau 'bds'", http.StatusBadRequest)
-		return
+	searcher, ok := LookupSearcher(algo)
+	if !ok {
+		return fmt.Errorf("Parameter 'algo' harus salah satu dari: %s", strings.Join(registeredSearcherNames(), ", "))
 	}
 	if mode != "shortest" && mode != "multiple" {
-		http.Error(w, "Parameter 'mode' harus 'shortest' atau 'multiple'", http.StatusBadRequest)
-		return
+		return errors.New("Parameter 'mode' harus 'shortest' atau 'multiple'")
+	}
+	if !supportsMode(searcher, mode) {
+		return fmt.Errorf("Algoritma '%s' tidak mendukung mode '%s'", algo, mode)
 	}
+	return nil
+}
 
-	maxRecipes := 1
-	if mode == "multiple" {
-		if maxRecipesStr != "" {
-			var convErr error
-			maxRecipes, convErr = strconv.Atoi(maxRecipesStr)
-			if convErr != nil || maxRecipes <= 0 {
-				http.Error(w, "Parameter 'max' harus berupa angka positif lebih besar dari 0 untuk mode 'multiple'", http.StatusBadRequest)
-				return
-			}
-		} else {
-			http.Error(w, "Parameter 'max' diperlukan untuk mode 'multiple'", http.StatusBadRequest)
-			return
-		}
+func capMaxRecipes(maxRecipes int) int {
+	if maxRecipes > searchLimits.MaxRecipes {
+		log.Printf("Parameter max=%d melebihi batas server, dipotong menjadi %d\n", maxRecipes, searchLimits.MaxRecipes)
+		return searchLimits.MaxRecipes
 	}
+	return maxRecipes
+}
 
+// limitMaxRecipes memotong 'max' ke batas server lalu ke jumlah pohon resep target. Dipakai oleh semua
+// endpoint yang menerima 'max' agar biaya yang dibebankan sama untuk permintaan yang sama.
+func limitMaxRecipes(targetElement string, maxRecipes int) int {
+	return boundMaxRecipesByTreeCount(targetElement, capMaxRecipes(maxRecipes))
+}
+
+// runSearch menjalankan pencarian yang parameternya sudah divalidasi dan menyusun responsnya.
+// Pada mode multiple, minDiversity > 0 membuat algoritma mengambil kandidat lebih banyak lalu disaring.
+func runSearch(targetElement, algo, mode string, maxRecipes int, minDiversity float64) MultiSearchResponse {
+	return runSearchWithOptions(targetElement, algo, mode, maxRecipes, minDiversity, SearchOptions{})
+}
+
+// runSearchWithOptions sama dengan runSearch, ditambah options untuk algoritma yang bisa dikonfigurasi.
+func runSearchWithOptions(targetElement, algo, mode string, maxRecipes int, minDiversity float64, options SearchOptions) MultiSearchResponse {
 	startTime := time.Now()
-	var singlePath []Recipe
-	var multiplePaths [][]Recipe
 	var nodesVisited int
 	var errSearch error
 	var pathFound bool
@@ -114,43 +187,65 @@ func searchHandler(w http.ResponseWriter, r *http.Request) {
 		Algorithm:    algo,
 		Mode:         mode,
 	}
+	fetchRecipes := maxRecipes
 	if mode == "multiple" {
 		response.MaxRecipes = maxRecipes
+		response.MinDiversity = minDiversity
+		fetchRecipes = diversityFetchCount(maxRecipes, minDiversity)
 	}
 
-	if algo == "bfs" {
-		if mode == "shortest" {
-			singlePath, nodesVisited, errSearch = FindPathBFS(targetElement)
-			response.Path = singlePath
-			pathFound = errSearch == nil && (len(singlePath) > 0 || (len(singlePath) == 0 && isBaseElement(targetElement)))
-		} else {
-			multiplePaths, nodesVisited, errSearch = FindMultiplePathsBFS(targetElement, maxRecipes)
-			response.Paths = multiplePaths
-			pathFound = errSearch == nil && (len(multiplePaths) > 0 || (len(multiplePaths) == 0 && isBaseElement(targetElement)))
+	searcher, ok := LookupSearcher(algo)
+	if !ok {
+		errSearch = fmt.Errorf("algoritma '%s' tidak terdaftar", algo)
+	} else if mode == "shortest" {
+		var result SearchResult
+		result, errSearch = withSearchOptions(searcher, options).Shortest(targetElement)
+		nodesVisited = result.NodesVisited
+		response.Iterations = result.Iterations
+		response.Beam = result.Beam
+		if len(result.Paths) > 0 {
+			response.Path = result.Paths[0]
 		}
-	} else if algo == "dfs" {
+		pathFound = errSearch == nil && (len(response.Path) > 0 || isBaseElement(targetElement))
+	} else {
+		var result SearchResult
+		result, errSearch = withSearchOptions(searcher, options).Multiple(targetElement, fetchRecipes)
+		nodesVisited = result.NodesVisited
+		response.Iterations = result.Iterations
+		response.Beam = result.Beam
+		response.Paths = result.Paths
+		response.Exhausted = result.Exhausted
+		response.Truncated = result.Truncated
+		pathFound = errSearch == nil && (len(response.Paths) > 0 || isBaseElement(targetElement))
+	}
+
+	duration := time.Since(startTime)
+
+	if enforcePathValidation && pathFound && !isBaseElement(targetElement) {
 		if mode == "shortest" {
-			singlePath, nodesVisited, errSearch = FindPathDFS(targetElement)
-			response.Path = singlePath
-			pathFound = errSearch == nil && (len(singlePath) > 0 || (len(singlePath) == 0 && isBaseElementDFS(targetElement)))
+			if err := ValidatePath(targetElement, response.Path); err != nil {
+				log.Printf("Validasi: jalur dari %s dibuang: %v\n", algo, err)
+				response.Path = nil
+				pathFound = false
+				errSearch = err
+			}
 		} else {
-			multiplePaths, nodesVisited, errSearch = FindMultiplePathsDFS(targetElement, maxRecipes)
-			response.Paths = multiplePaths
-			pathFound = errSearch == nil && (len(multiplePaths) > 0 || (len(multiplePaths) == 0 && isBaseElementDFS(targetElement)))
+			response.Paths = filterValidPaths(targetElement, algo, response.Paths)
+			if len(response.Paths) == 0 {
+				pathFound = false
+				errSearch = fmt.Errorf("semua jalur dari %s ke '%s' tidak valid", algo, targetElement)
+			}
 		}
-	} else if algo == "bds" {
-		if mode == "shortest" {
-			singlePath, nodesVisited, errSearch = FindPathBDS(targetElement)
-			response.Path = singlePath
-			pathFound = errSearch == nil && singlePath != nil && (len(singlePath) > 0 || (len(singlePath) == 0 && isBaseElement(targetElement)))
-		} else {
-			multiplePaths, nodesVisited, errSearch = FindMultiplePathsBDS(targetElement, maxRecipes)
-			response.Paths = multiplePaths
-			pathFound = errSearch == nil && multiplePaths != nil && (len(multiplePaths) > 0 || (len(multiplePaths) == 0 && isBaseElement(targetElement)))
+	}
+
+	if mode == "multiple" && pathFound {
+		applyDiversity(&response, maxRecipes, minDiversity)
+		if len(response.Paths) >= maxRecipes {
+			response.Exhausted = false
+			response.Truncated = false
 		}
 	}
 
-	duration := time.Since(startTime)
 	log.Printf("Pencarian selesai: Durasi=%v, Nodes Dikeluarkan=%d, Path Ditemukan=%t, Error=%v\n", duration, nodesVisited, pathFound, errSearch)
 
 	response.PathFound = pathFound
@@ -189,8 +284,12 @@ func searchHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	return response
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
 	w.Header().Set("Content-Type", "application/json")
-	jsonResponse, jsonErr := json.MarshalIndent(response, "", "  ")
+	jsonResponse, jsonErr := json.MarshalIndent(payload, "", "  ")
 	if jsonErr != nil {
 		log.Printf("Error saat marshal JSON response: %v", jsonErr)
 		http.Error(w, "Internal Server Error saat membuat respons JSON", http.StatusInternalServerError)
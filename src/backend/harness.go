@@ -0,0 +1,233 @@
+// src/backend/harness.go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var harnessHeader = []string{"element", "algorithm", "tier", "found", "path_length", "path_depth", "nodes_visited", "duration_micros", "error"}
+
+type harnessRecord struct {
+	Element        string
+	Algorithm      string
+	Tier           int
+	Found          bool
+	PathLength     int
+	PathDepth      int
+	NodesVisited   int
+	DurationMicros int64
+	Error          string
+}
+
+func (h harnessRecord) key() string {
+	return h.Algorithm + "|" + h.Element
+}
+
+func (h harnessRecord) toCSV() []string {
+	return []string{
+		h.Element,
+		h.Algorithm,
+		strconv.Itoa(h.Tier),
+		strconv.FormatBool(h.Found),
+		strconv.Itoa(h.PathLength),
+		strconv.Itoa(h.PathDepth),
+		strconv.Itoa(h.NodesVisited),
+		strconv.FormatInt(h.DurationMicros, 10),
+		h.Error,
+	}
+}
+
+// pathDepth menghitung kedalaman (jumlah tingkat kombinasi) rencana yang sudah terurut.
+func pathDepth(path []Recipe) int {
+	depth := make(map[string]int)
+	maxDepth := 0
+	for _, recipe := range path {
+		d := 1 + max(depth[recipe.Ingredient1], depth[recipe.Ingredient2])
+		depth[recipe.Result] = d
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+	return maxDepth
+}
+
+func flattenRecipeMap(recipesByResult map[string][]Recipe) []Recipe {
+	results := make([]string, 0, len(recipesByResult))
+	for result := range recipesByResult {
+		results = append(results, result)
+	}
+	sort.Strings(results)
+
+	var recipes []Recipe
+	for _, result := range results {
+		recipes = append(recipes, recipesByResult[result]...)
+	}
+	return recipes
+}
+
+// runHarness menyapu seluruh elemen dengan setiap algoritma dan menulis hasilnya ke CSV.
+// Jika baselinePath diisi, hasil dibandingkan dengan baseline dan error dikembalikan saat ada regresi.
+func runHarness(dataDir, outPath, baselinePath string, timeFactor float64) error {
+	if err := InitData(dataDir); err != nil {
+		return err
+	}
+	BuildGraph(GetRecipeMap())
+
+	tiers, _ := calculateElementTiers(flattenRecipeMap(GetRecipeMap()), baseElements)
+	elements := make([]string, 0, len(GetAllElementNames()))
+	for name := range GetAllElementNames() {
+		elements = append(elements, name)
+	}
+	sort.Strings(elements)
+
+	searchers := searchersForMode("shortest")
+	log.Printf("Harness: menjalankan %d algoritma untuk %d elemen...\n", len(searchers), len(elements))
+	var records []harnessRecord
+	// Log pencarian sangat banyak, log pelacakan dibuang selama pengukuran.
+	restoreTrace := silenceTrace()
+	for i, element := range elements {
+		for _, searcher := range searchers {
+			ResetCaches()
+			start := time.Now()
+			result, errSearch := searcher.Shortest(element)
+			elapsed := time.Since(start)
+
+			var path []Recipe
+			if len(result.Paths) > 0 {
+				path = result.Paths[0]
+			}
+			record := harnessRecord{
+				Element:        element,
+				Algorithm:      searcher.Name(),
+				Tier:           tiers[element],
+				Found:          errSearch == nil && (len(path) > 0 || isBaseElement(element)),
+				PathLength:     len(path),
+				PathDepth:      pathDepth(path),
+				NodesVisited:   result.NodesVisited,
+				DurationMicros: elapsed.Microseconds(),
+			}
+			if errSearch != nil {
+				record.Error = errSearch.Error()
+			}
+			records = append(records, record)
+		}
+		if (i+1)%100 == 0 {
+			log.Printf("Harness: %d/%d elemen selesai\n", i+1, len(elements))
+		}
+	}
+	restoreTrace()
+	ResetCaches()
+
+	if err := writeHarnessCSV(outPath, records); err != nil {
+		return err
+	}
+	log.Printf("Harness: %d baris ditulis ke %s\n", len(records), outPath)
+
+	if baselinePath == "" {
+		return nil
+	}
+	baseline, err := readHarnessCSV(baselinePath)
+	if err != nil {
+		return err
+	}
+	regressions := compareHarnessRecords(baseline, records, timeFactor)
+	for _, regression := range regressions {
+		log.Printf("REGRESI: %s\n", regression)
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("%d regresi ditemukan dibanding baseline %s", len(regressions), baselinePath)
+	}
+	log.Printf("Harness: tidak ada regresi dibanding baseline %s\n", baselinePath)
+	return nil
+}
+
+func writeHarnessCSV(outPath string, records []harnessRecord) error {
+	file, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("gagal membuat file harness '%s': %w", outPath, err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(harnessHeader); err != nil {
+		return err
+	}
+	for _, record := range records {
+		if err := writer.Write(record.toCSV()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func readHarnessCSV(path string) (map[string]harnessRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membuka baseline '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca baseline '%s': %w", path, err)
+	}
+	records := make(map[string]harnessRecord)
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+		if len(row) != len(harnessHeader) {
+			return nil, fmt.Errorf("baris %d baseline memiliki %d kolom, seharusnya %d", i+1, len(row), len(harnessHeader))
+		}
+		record := harnessRecord{Element: row[0], Algorithm: row[1], Error: row[8]}
+		record.Tier, _ = strconv.Atoi(row[2])
+		record.Found, _ = strconv.ParseBool(row[3])
+		record.PathLength, _ = strconv.Atoi(row[4])
+		record.PathDepth, _ = strconv.Atoi(row[5])
+		record.NodesVisited, _ = strconv.Atoi(row[6])
+		record.DurationMicros, _ = strconv.ParseInt(row[7], 10, 64)
+		records[record.key()] = record
+	}
+	return records, nil
+}
+
+// Durasi di bawah harnessTimeSlack dianggap derau pengukuran dan tidak dilaporkan.
+const harnessTimeSlack = 5 * time.Millisecond
+
+func compareHarnessRecords(baseline map[string]harnessRecord, current []harnessRecord, timeFactor float64) []string {
+	var regressions []string
+	for _, now := range current {
+		before, exists := baseline[now.key()]
+		if !exists {
+			continue
+		}
+		label := fmt.Sprintf("%s/%s", now.Algorithm, now.Element)
+		if before.Found && !now.Found {
+			regressions = append(regressions, fmt.Sprintf("%s: sebelumnya ditemukan, sekarang tidak (%s)", label, now.Error))
+			continue
+		}
+		if !now.Found {
+			continue
+		}
+		if now.PathLength > before.PathLength {
+			regressions = append(regressions, fmt.Sprintf("%s: panjang jalur naik %d -> %d", label, before.PathLength, now.PathLength))
+		}
+		if now.PathDepth > before.PathDepth {
+			regressions = append(regressions, fmt.Sprintf("%s: kedalaman jalur naik %d -> %d", label, before.PathDepth, now.PathDepth))
+		}
+		if timeFactor > 0 {
+			limit := float64(before.DurationMicros)*timeFactor + float64(harnessTimeSlack.Microseconds())
+			if float64(now.DurationMicros) > limit {
+				regressions = append(regressions, fmt.Sprintf("%s: waktu naik %dus -> %dus", label, before.DurationMicros, now.DurationMicros))
+			}
+		}
+	}
+	return regressions
+}
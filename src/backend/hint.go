@@ -0,0 +1,162 @@
+// src/backend/hint.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Tingkat petunjuk: satu bahan, kedua bahan, atau kombinasi lengkap beserta hasilnya.
+const (
+	hintLevelIngredient  = 1
+	hintLevelIngredients = 2
+	hintLevelCombination = 3
+)
+
+type HintRequest struct {
+	Target    string   `json:"target"`
+	Inventory []string `json:"inventory"`
+	Level     int      `json:"level"`
+}
+
+// HintResponse berisi kombinasi berikutnya menuju target. Field yang belum dibuka sesuai Level dikosongkan.
+// Depth adalah kedalaman target dihitung dari inventaris, StepsRemaining jumlah kombinasi pada rencana
+// terdangkal yang dipakai untuk memilih petunjuk (termasuk kombinasi petunjuk ini). StepsRemaining hanya
+// perkiraan: petunjuk berikutnya dihitung ulang dari inventaris baru dan bisa memakai rencana lain dengan
+// kedalaman yang sama.
+type HintResponse struct {
+	Target         string `json:"target"`
+	Level          int    `json:"level"`
+	Discovered     bool   `json:"discovered"`
+	Ingredient1    string `json:"ingredient1,omitempty"`
+	Ingredient2    string `json:"ingredient2,omitempty"`
+	Result         string `json:"result,omitempty"`
+	Depth          int    `json:"depth"`
+	StepsRemaining int    `json:"stepsRemaining"`
+}
+
+// NextHint mencari kombinasi berikutnya yang bisa dibuat dari inventaris dan berada pada rencana dengan
+// kedalaman minimum menuju target. Closure maju BDS dimulai dari inventaris sehingga kedalaman setiap elemen
+// dihitung dari elemen yang sudah dimiliki pemain. Dari target, resep parent diikuti ke bahan yang paling
+// dalam sampai tersisa resep yang kedua bahannya sudah ada di inventaris. Fungsi ini tidak menyimpan state,
+// jadi cukup dipanggil ulang setelah setiap langkah pemain.
+func NextHint(target string, rawInventory []string, level int) (HintResponse, error) {
+	if level == 0 {
+		level = hintLevelCombination
+	}
+	if level < hintLevelIngredient || level > hintLevelCombination {
+		return HintResponse{}, fmt.Errorf("Parameter 'level' harus antara %d dan %d", hintLevelIngredient, hintLevelCombination)
+	}
+	g := GetCompactGraph()
+	if g == nil {
+		return HintResponse{}, errors.New("data resep belum diinisialisasi")
+	}
+	name, err := resolveExistingElement("target", target)
+	if err != nil {
+		return HintResponse{}, err
+	}
+	inventory, err := resolveInventory(rawInventory)
+	if err != nil {
+		return HintResponse{}, err
+	}
+
+	response := HintResponse{Target: name, Level: level}
+	targetID, exists := g.ID(name)
+	if !exists {
+		return response, fmt.Errorf("elemen '%s' tidak dapat dibuat dari inventaris", name)
+	}
+	known := make([]bool, g.NumElements())
+	for _, element := range inventory {
+		if id, exists := g.ID(element); exists {
+			known[id] = true
+		}
+	}
+	if known[targetID] {
+		response.Discovered = true
+		return response, nil
+	}
+
+	forward := newBDSForwardFrom(g, known)
+	for forward.depth[targetID] < 0 {
+		if forward.grow() == 0 {
+			return response, fmt.Errorf("elemen '%s' tidak dapat dibuat dari inventaris", name)
+		}
+	}
+	response.Depth = int(forward.depth[targetID])
+	response.StepsRemaining = len(g.recipePathFromParents(forward.parent, forward.depth, targetID))
+
+	element := targetID
+	for forward.depth[element] > 1 {
+		recipe := g.recipes[forward.parent[element]]
+		element = recipe.ingredient1
+		if forward.depth[recipe.ingredient2] > forward.depth[element] {
+			element = recipe.ingredient2
+		}
+	}
+	next := g.Recipe(forward.parent[element])
+	if next.Ingredient1 > next.Ingredient2 {
+		next.Ingredient1, next.Ingredient2 = next.Ingredient2, next.Ingredient1
+	}
+	response.Ingredient1 = next.Ingredient1
+	if level >= hintLevelIngredients {
+		response.Ingredient2 = next.Ingredient2
+	}
+	if level >= hintLevelCombination {
+		response.Result = next.Result
+	}
+	return response, nil
+}
+
+// hintHandler melayani /api/hint?target=X&inventory=A,B&level=1 atau POST dengan body HintRequest.
+// ?session=<id> memakai inventaris sesi permainan sebagai ganti inventory.
+func hintHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+	var request HintRequest
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet:
+		query := r.URL.Query()
+		request.Target = query.Get("target")
+		request.Inventory = splitInventoryParam(query.Get("inventory"))
+		if rawLevel := query.Get("level"); rawLevel != "" {
+			level, err := strconv.Atoi(rawLevel)
+			if err != nil {
+				http.Error(w, "Parameter 'level' harus berupa angka", http.StatusBadRequest)
+				return
+			}
+			request.Level = level
+		}
+	case http.MethodPost:
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if id := r.URL.Query().Get("session"); id != "" {
+		inventory, err := sessionInventory(id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		request.Inventory = inventory
+	}
+	response, err := NextHint(request.Target, request.Inventory, request.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, response)
+}
@@ -0,0 +1,74 @@
+// src/backend/hint_test.go
+package main
+
+import "testing"
+
+// Mengikuti petunjuk berulang kali (hasil dimasukkan ke inventaris) harus sampai ke target, setiap petunjuk
+// hanya memakai bahan yang sudah dimiliki, dan kedalaman target tidak pernah bertambah.
+func TestFollowingHintsReachesTarget(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	targets := benchmarkTargets
+	if !testing.Short() {
+		targets = sortedElementNames()
+	}
+	for _, target := range targets {
+		if isBaseElement(target) {
+			continue
+		}
+		var inventory []string
+		owned := map[string]bool{"Air": true, "Earth": true, "Fire": true, "Water": true}
+		first, err := NextHint(target, nil, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", target, err)
+		}
+		previousDepth := first.Depth
+		for {
+			hint, err := NextHint(target, inventory, 0)
+			if err != nil {
+				t.Fatalf("%s: %v", target, err)
+			}
+			if hint.Discovered {
+				break
+			}
+			if !owned[hint.Ingredient1] || !owned[hint.Ingredient2] || owned[hint.Result] {
+				t.Fatalf("%s: petunjuk %s + %s -> %s tidak sesuai inventaris", target, hint.Ingredient1, hint.Ingredient2, hint.Result)
+			}
+			if hint.Depth > previousDepth {
+				t.Fatalf("%s: kedalaman naik dari %d ke %d", target, previousDepth, hint.Depth)
+			}
+			previousDepth = hint.Depth
+			owned[hint.Result] = true
+			inventory = append(inventory, hint.Result)
+		}
+	}
+}
+
+func TestHintLevelsRevealGradually(t *testing.T) {
+	loadTestDataset(t)
+
+	full, err := NextHint("mud", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Ingredient1 != "Earth" || full.Ingredient2 != "Water" || full.Result != "Mud" || full.Depth != 1 || full.StepsRemaining != 1 {
+		t.Errorf("petunjuk Mud salah: %+v", full)
+	}
+	one, err := NextHint("Mud", nil, hintLevelIngredient)
+	if err != nil || one.Ingredient1 != "Earth" || one.Ingredient2 != "" || one.Result != "" {
+		t.Errorf("level 1 seharusnya hanya membuka satu bahan: %+v %v", one, err)
+	}
+	two, err := NextHint("Mud", nil, hintLevelIngredients)
+	if err != nil || two.Ingredient2 != "Water" || two.Result != "" {
+		t.Errorf("level 2 seharusnya membuka kedua bahan tanpa hasil: %+v %v", two, err)
+	}
+
+	done, err := NextHint("Mud", []string{"mud"}, 0)
+	if err != nil || !done.Discovered || done.Ingredient1 != "" {
+		t.Errorf("target yang sudah dimiliki seharusnya ditandai ditemukan: %+v %v", done, err)
+	}
+	if _, err := NextHint("Mud", nil, 4); err == nil {
+		t.Error("level di luar rentang seharusnya ditolak")
+	}
+}
@@ -0,0 +1,180 @@
+// src/backend/iddfs.go
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+func init() {
+	RegisterSearcher(funcSearcher{
+		name:         "iddfs",
+		description:  "Iterative deepening DFS dengan stack eksplisit; kedalaman rencana selalu minimum",
+		capabilities: SearchCapabilities{Modes: []string{"shortest"}, Deterministic: true},
+		shortestResult: func(target string) (SearchResult, error) {
+			path, iterations, nodesVisited, err := FindPathIDDFS(target)
+			result := SearchResult{NodesVisited: nodesVisited, Iterations: iterations}
+			if path != nil {
+				result.Paths = [][]Recipe{path}
+			}
+			return result, err
+		},
+	})
+}
+
+// SearchIteration mencatat satu putaran pencarian berbatas kedalaman.
+type SearchIteration struct {
+	Bound          int   `json:"bound"`
+	NodesVisited   int   `json:"nodesVisited"`
+	DurationMicros int64 `json:"durationMicros"`
+	Found          bool  `json:"found"`
+}
+
+type iddfsFrame struct {
+	element string
+	bound   int
+	recipes []Recipe
+	next    int
+	// stage 0 memeriksa bahan pertama resep ke-next, stage 1 bahan kedua.
+	stage int
+}
+
+// iddfsState menyimpan memo yang tetap berlaku antar putaran: elemen yang gagal dengan batas b pasti
+// gagal untuk batas yang lebih kecil, dan elemen yang berhasil dengan batas b berhasil untuk batas lebih besar.
+type iddfsState struct {
+	recipesByResult map[string][]Recipe
+	sortedRecipes   map[string][]Recipe
+	failedBound     map[string]int
+	solvedBound     map[string]int
+	chosen          map[string]Recipe
+}
+
+func (s *iddfsState) recipesFor(element string) []Recipe {
+	if recipes, exists := s.sortedRecipes[element]; exists {
+		return recipes
+	}
+	recipes := append([]Recipe(nil), s.recipesByResult[element]...)
+	sort.Slice(recipes, func(i, j int) bool { return getRecipeID(recipes[i]) < getRecipeID(recipes[j]) })
+	s.sortedRecipes[element] = recipes
+	return recipes
+}
+
+// known menjawab tanpa ekspansi jika hasilnya sudah pasti dari elemen dasar atau memo.
+func (s *iddfsState) known(element string, bound int) (ok bool, decided bool) {
+	if isBaseElement(element) {
+		return true, true
+	}
+	if solved, exists := s.solvedBound[element]; exists && solved <= bound {
+		return true, true
+	}
+	if failed, exists := s.failedBound[element]; exists && failed >= bound {
+		return false, true
+	}
+	if bound <= 0 {
+		return false, true
+	}
+	return false, false
+}
+
+// solve memeriksa apakah element bisa dibuat dengan kedalaman paling banyak bound memakai stack eksplisit.
+func (s *iddfsState) solve(element string, bound int) (bool, int) {
+	if ok, decided := s.known(element, bound); decided {
+		return ok, 0
+	}
+	stack := []iddfsFrame{{element: element, bound: bound, recipes: s.recipesFor(element)}}
+	nodesVisited := 1
+	returned, returnValue := false, false
+
+	for len(stack) > 0 {
+		frame := &stack[len(stack)-1]
+		var childOK bool
+		if returned {
+			returned = false
+			childOK = returnValue
+		} else {
+			if frame.next >= len(frame.recipes) {
+				if failed, exists := s.failedBound[frame.element]; !exists || frame.bound > failed {
+					s.failedBound[frame.element] = frame.bound
+				}
+				stack = stack[:len(stack)-1]
+				returned, returnValue = true, false
+				continue
+			}
+			recipe := frame.recipes[frame.next]
+			ingredient := recipe.Ingredient1
+			if frame.stage == 1 {
+				ingredient = recipe.Ingredient2
+			}
+			ok, decided := s.known(ingredient, frame.bound-1)
+			if !decided {
+				nodesVisited++
+				stack = append(stack, iddfsFrame{element: ingredient, bound: frame.bound - 1, recipes: s.recipesFor(ingredient)})
+				continue
+			}
+			childOK = ok
+		}
+
+		if !childOK {
+			frame.next++
+			frame.stage = 0
+			continue
+		}
+		if frame.stage == 0 {
+			frame.stage = 1
+			continue
+		}
+		// Frame yang sama untuk elemen ini bisa saja sudah diselesaikan lebih dulu dengan batas lebih kecil
+		// oleh frame di atasnya; solusi itu dipertahankan karena elemen lain mungkin sudah bergantung padanya.
+		if solved, exists := s.solvedBound[frame.element]; !exists || frame.bound < solved {
+			s.solvedBound[frame.element] = frame.bound
+			s.chosen[frame.element] = frame.recipes[frame.next]
+		}
+		stack = stack[:len(stack)-1]
+		returned, returnValue = true, true
+	}
+	return returnValue, nodesVisited
+}
+
+// FindPathIDDFS menaikkan batas kedalaman satu per satu sampai target bisa dibuat, sehingga rencana yang
+// ditemukan memiliki kedalaman minimum (sama dengan tier elemen).
+func FindPathIDDFS(targetElement string) ([]Recipe, []SearchIteration, int, error) {
+	tracef("IDDFS: Mencari jalur terpendek ke: %s\n", targetElement)
+	recipesByResult := GetRecipeMap()
+	if recipesByResult == nil {
+		return nil, nil, 0, errors.New("map resep belum diinisialisasi")
+	}
+	if isBaseElement(targetElement) {
+		return []Recipe{}, nil, 0, nil
+	}
+
+	state := &iddfsState{
+		recipesByResult: recipesByResult,
+		sortedRecipes:   make(map[string][]Recipe),
+		failedBound:     make(map[string]int),
+		solvedBound:     make(map[string]int),
+		chosen:          make(map[string]Recipe),
+	}
+	// Rencana tanpa elemen berulang tidak pernah lebih dalam dari jumlah elemen hasil.
+	maxBound := len(recipesByResult) + 1
+	var iterations []SearchIteration
+	totalNodes := 0
+	for bound := 1; bound <= maxBound; bound++ {
+		start := time.Now()
+		found, nodesVisited := state.solve(targetElement, bound)
+		totalNodes += nodesVisited
+		iterations = append(iterations, SearchIteration{
+			Bound:          bound,
+			NodesVisited:   nodesVisited,
+			DurationMicros: time.Since(start).Microseconds(),
+			Found:          found,
+		})
+		tracef("IDDFS: Batas %d, %d node, ditemukan=%t\n", bound, nodesVisited, found)
+		if found {
+			path := buildRecipePath(state.chosen, targetElement, state.solvedBound)
+			return path, iterations, totalNodes, nil
+		}
+	}
+	return nil, iterations, totalNodes, fmt.Errorf("path to element '%s' not found", targetElement)
+}
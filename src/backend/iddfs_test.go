@@ -0,0 +1,53 @@
+// src/backend/iddfs_test.go
+package main
+
+import "testing"
+
+func TestIDDFSFindsTierDepthPlans(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	elements := benchmarkTargets
+	if !testing.Short() {
+		elements = sortedElementNames()
+	}
+	tiers, _ := calculateElementTiers(flattenRecipeMap(GetRecipeMap()), baseElements)
+	for _, element := range elements {
+		path, iterations, nodes, err := FindPathIDDFS(element)
+		if err != nil {
+			t.Errorf("iddfs: tidak ada jalur ke %s: %v", element, err)
+			continue
+		}
+		if err := ValidatePath(element, path); err != nil {
+			t.Errorf("iddfs: %v", err)
+			continue
+		}
+		if pathDepth(path) != tiers[element] {
+			t.Errorf("iddfs: kedalaman %s = %d, tier %d", element, pathDepth(path), tiers[element])
+		}
+		if isBaseElement(element) {
+			continue
+		}
+
+		sum := 0
+		for i, iteration := range iterations {
+			sum += iteration.NodesVisited
+			if iteration.Bound != i+1 || iteration.Found != (i == len(iterations)-1) {
+				t.Errorf("iddfs: %s: putaran #%d tidak berurutan: %+v", element, i+1, iteration)
+			}
+		}
+		if len(iterations) != tiers[element] || sum != nodes {
+			t.Errorf("iddfs: %s: %d putaran (%d node), tier %d (%d node)", element, len(iterations), sum, tiers[element], nodes)
+		}
+	}
+}
+
+func TestRunSearchReportsIDDFSIterations(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	response := runSearch("Brick", "iddfs", "shortest", 1, 0)
+	if !response.PathFound || len(response.Iterations) == 0 || !response.Iterations[len(response.Iterations)-1].Found {
+		t.Errorf("respons iddfs seharusnya memuat statistik putaran: %+v", response)
+	}
+}
@@ -0,0 +1,229 @@
+// src/backend/impact.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+)
+
+type ImpactRequest struct {
+	RemoveElements []string `json:"removeElements,omitempty"`
+	RemoveRecipes  []Recipe `json:"removeRecipes,omitempty"`
+}
+
+type DepthChange struct {
+	Element    string `json:"element"`
+	TierBefore int    `json:"tierBefore"`
+	TierAfter  int    `json:"tierAfter"`
+	Delta      int    `json:"delta"`
+}
+
+type ImpactSummary struct {
+	RecipesRemoved      int `json:"recipesRemoved"`
+	ElementsUnreachable int `json:"elementsUnreachable"`
+	RecipesUnmakeable   int `json:"recipesUnmakeable"`
+	DepthIncreases      int `json:"depthIncreases"`
+	TierFilterDrops     int `json:"tierFilterDrops"`
+}
+
+type ImpactReport struct {
+	DatasetVersion      string        `json:"datasetVersion"`
+	RemovedElements     []string      `json:"removedElements"`
+	RemovedRecipes      []string      `json:"removedRecipes"`
+	UnknownElements     []string      `json:"unknownElements,omitempty"`
+	UnknownRecipes      []string      `json:"unknownRecipes,omitempty"`
+	UnreachableElements []string      `json:"unreachableElements"`
+	UnmakeableRecipes   []string      `json:"unmakeableRecipes"`
+	DepthIncreases      []DepthChange `json:"depthIncreases"`
+	TierFilterDrops     []string      `json:"tierFilterDrops"`
+	Summary             ImpactSummary `json:"summary"`
+}
+
+// makeableElementSet mengembalikan elemen dasar yang tersedia beserta semua hasil dari resep yang bisa dibuat.
+func makeableElementSet(recipes []Recipe, bases []string) map[string]bool {
+	makeable := make(map[string]bool, len(recipes)+len(bases))
+	for _, base := range bases {
+		makeable[base] = true
+	}
+	for _, recipe := range recipes {
+		makeable[recipe.Result] = true
+	}
+	return makeable
+}
+
+func sortedRecipeIDs(recipes []Recipe) []string {
+	ids := make([]string, 0, len(recipes))
+	for _, recipe := range recipes {
+		ids = append(ids, getRecipeID(recipe))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// AnalyzeImpact menjalankan ulang filter ketercapaian dan tier pada salinan dataset tanpa resep/elemen yang
+// dihapus, lalu membandingkannya dengan kondisi sekarang. Data global tidak diubah.
+func AnalyzeImpact(req ImpactRequest) (ImpactReport, error) {
+	recipesByResult := GetRecipeMap()
+	if recipesByResult == nil {
+		return ImpactReport{}, errors.New("map resep belum diinisialisasi")
+	}
+	if len(req.RemoveElements) == 0 && len(req.RemoveRecipes) == 0 {
+		return ImpactReport{}, errors.New("tidak ada resep atau elemen yang dihapus")
+	}
+
+	report := ImpactReport{
+		DatasetVersion:      GetDatasetVersion(),
+		RemovedElements:     []string{},
+		UnreachableElements: []string{},
+		UnmakeableRecipes:   []string{},
+		DepthIncreases:      []DepthChange{},
+		TierFilterDrops:     []string{},
+	}
+
+	removedElements := make(map[string]bool)
+	for _, raw := range req.RemoveElements {
+		element := resolveElementName(raw)
+		if !IsElementExists(element) {
+			report.UnknownElements = append(report.UnknownElements, raw)
+			continue
+		}
+		if !removedElements[element] {
+			removedElements[element] = true
+			report.RemovedElements = append(report.RemovedElements, element)
+		}
+	}
+	sort.Strings(report.RemovedElements)
+
+	current := flattenRecipeMap(recipesByResult)
+	currentIDs := make(map[string]bool, len(current))
+	for _, recipe := range current {
+		currentIDs[getRecipeID(recipe)] = true
+	}
+	removedRecipeIDs := make(map[string]bool)
+	for _, recipe := range req.RemoveRecipes {
+		id := getRecipeID(recipe)
+		if !currentIDs[id] {
+			report.UnknownRecipes = append(report.UnknownRecipes, id)
+			continue
+		}
+		removedRecipeIDs[id] = true
+	}
+
+	var remaining, removed []Recipe
+	for _, recipe := range current {
+		if removedRecipeIDs[getRecipeID(recipe)] || removedElements[recipe.Result] ||
+			removedElements[recipe.Ingredient1] || removedElements[recipe.Ingredient2] {
+			removed = append(removed, recipe)
+			continue
+		}
+		remaining = append(remaining, recipe)
+	}
+	report.RemovedRecipes = sortedRecipeIDs(removed)
+
+	var remainingBases []string
+	for _, base := range baseElements {
+		if !removedElements[base] {
+			remainingBases = append(remainingBases, base)
+		}
+	}
+
+	keptBefore, _ := filterUnmakeablePaths(current, baseElements)
+	keptAfter, unmakeable := filterUnmakeablePaths(remaining, remainingBases)
+	report.UnmakeableRecipes = sortedRecipeIDs(unmakeable)
+
+	makeableBefore := makeableElementSet(keptBefore, baseElements)
+	makeableAfter := makeableElementSet(keptAfter, remainingBases)
+	for element := range makeableBefore {
+		if !makeableAfter[element] && !removedElements[element] {
+			report.UnreachableElements = append(report.UnreachableElements, element)
+		}
+	}
+	sort.Strings(report.UnreachableElements)
+
+	// calculateElementTiers memberi tier sangat tinggi untuk elemen tanpa tier, jadi hanya elemen yang
+	// masih bisa dibuat yang dibandingkan.
+	tiersBefore, _ := calculateElementTiers(keptBefore, baseElements)
+	tiersAfter, _ := calculateElementTiers(keptAfter, remainingBases)
+	for element := range makeableAfter {
+		if !makeableBefore[element] {
+			continue
+		}
+		if delta := tiersAfter[element] - tiersBefore[element]; delta > 0 {
+			report.DepthIncreases = append(report.DepthIncreases, DepthChange{
+				Element:    element,
+				TierBefore: tiersBefore[element],
+				TierAfter:  tiersAfter[element],
+				Delta:      delta,
+			})
+		}
+	}
+	sort.Slice(report.DepthIncreases, func(i, j int) bool {
+		if report.DepthIncreases[i].Delta != report.DepthIncreases[j].Delta {
+			return report.DepthIncreases[i].Delta > report.DepthIncreases[j].Delta
+		}
+		return report.DepthIncreases[i].Element < report.DepthIncreases[j].Element
+	})
+
+	// Resep yang lolos filter tier sekarang tetapi akan dibuang filter tier setelah penghapusan.
+	_, droppedBefore := filterByTierLogic(keptBefore, tiersBefore)
+	_, droppedAfter := filterByTierLogic(keptAfter, tiersAfter)
+	alreadyDropped := make(map[string]bool, len(droppedBefore))
+	for _, recipe := range droppedBefore {
+		alreadyDropped[getRecipeID(recipe)] = true
+	}
+	for _, id := range sortedRecipeIDs(droppedAfter) {
+		if !alreadyDropped[id] {
+			report.TierFilterDrops = append(report.TierFilterDrops, id)
+		}
+	}
+
+	report.Summary = ImpactSummary{
+		RecipesRemoved:      len(report.RemovedRecipes),
+		ElementsUnreachable: len(report.UnreachableElements),
+		RecipesUnmakeable:   len(report.UnmakeableRecipes),
+		DepthIncreases:      len(report.DepthIncreases),
+		TierFilterDrops:     len(report.TierFilterDrops),
+	}
+	return report, nil
+}
+
+func impactHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ImpactRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+	if err := decoder.Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	release, ok := acquireSearchSlot(w, r, datasetPassCost)
+	if !ok {
+		return
+	}
+	defer release()
+
+	report, err := AnalyzeImpact(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	log.Printf("Analisis dampak: %d resep dihapus, %d elemen tidak terjangkau, %d elemen bertambah dalam\n",
+		report.Summary.RecipesRemoved, report.Summary.ElementsUnreachable, report.Summary.DepthIncreases)
+	writeJSON(w, report)
+}
@@ -0,0 +1,63 @@
+// src/backend/impact_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeImpactDoesNotMutateDataset(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	versionBefore := GetDatasetVersion()
+	recipesBefore := flattenRecipeMap(GetRecipeMap())
+
+	report, err := AnalyzeImpact(ImpactRequest{
+		RemoveElements: []string{"lake", "Unobtainium"},
+		RemoveRecipes:  []Recipe{{Result: "Mud", Ingredient1: "Earth", Ingredient2: "Water"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(report.RemovedElements, []string{"Lake"}) || !reflect.DeepEqual(report.UnknownElements, []string{"Unobtainium"}) {
+		t.Errorf("elemen yang dihapus/tidak dikenal salah: %v %v", report.RemovedElements, report.UnknownElements)
+	}
+
+	unreachable := make(map[string]bool, len(report.UnreachableElements))
+	for _, element := range report.UnreachableElements {
+		unreachable[element] = true
+	}
+	if !unreachable["Mud"] || unreachable["Lake"] {
+		t.Errorf("Mud seharusnya tidak terjangkau dan Lake dilaporkan sebagai elemen yang dihapus")
+	}
+	for _, change := range report.DepthIncreases {
+		if change.Delta <= 0 || unreachable[change.Element] {
+			t.Errorf("perubahan kedalaman tidak valid: %+v", change)
+		}
+	}
+
+	if GetDatasetVersion() != versionBefore || !reflect.DeepEqual(flattenRecipeMap(GetRecipeMap()), recipesBefore) {
+		t.Error("analisis dampak tidak boleh mengubah dataset")
+	}
+}
+
+func TestAnalyzeImpactDepthGrowth(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	// Steam punya dua resep: Water+Fire (tier 1) dan Water+Lava. Tanpa resep pertama Steam menjadi lebih dalam.
+	report, err := AnalyzeImpact(ImpactRequest{RemoveRecipes: []Recipe{{Result: "Steam", Ingredient1: "Water", Ingredient2: "Fire"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, change := range report.DepthIncreases {
+		if change.Element == "Steam" {
+			found = change.TierBefore == 1 && change.TierAfter > 1
+		}
+	}
+	if !found || len(report.UnreachableElements) != 0 {
+		t.Errorf("Steam seharusnya bertambah dalam tanpa elemen yang hilang: %+v", report.Summary)
+	}
+}
@@ -0,0 +1,297 @@
+// src/backend/kbest.go
+package main
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func init() {
+	RegisterSearcher(funcSearcher{
+		name:         "kbest",
+		description:  "Enumerasi best-first pohon resep terkecil; jalur ke-i selalu sekecil mungkin",
+		capabilities: SearchCapabilities{Modes: []string{"shortest", "multiple"}, Optimal: true, Deterministic: true, ReportsExhausted: true},
+		shortest: func(target string) ([]Recipe, int, error) {
+			result, nodesVisited, err := FindKBestPaths(target, 1)
+			if len(result.Paths) == 0 {
+				return nil, nodesVisited, err
+			}
+			return result.Paths[0], nodesVisited, err
+		},
+		multiple: func(target string, maxRecipes int) (SearchResult, error) {
+			result, nodesVisited, err := FindKBestPaths(target, maxRecipes)
+			return SearchResult{Paths: result.Paths, NodesVisited: nodesVisited, Exhausted: result.Exhausted, Truncated: result.Truncated}, err
+		},
+		// satu goroutine, tetapi antrean state tumbuh seiring k
+		cost: func(maxRecipes int) int64 { return int64(1 + maxRecipes/10) },
+	})
+}
+
+// Batas jumlah state yang dikeluarkan dari antrean agar permintaan k yang sangat besar tetap berhenti.
+const kBestMaxExpansions = 200000
+
+// Pohon resep: setiap elemen bukan dasar memilih satu resep, dan kedua bahannya punya subpohon sendiri.
+// Sebuah elemen tidak boleh muncul lagi di bawah dirinya sendiri, sehingga siklus pada graf resep tidak
+// menghasilkan pohon tak hingga. Ukuran pohon = jumlah simpul resep (subpohon yang sama dihitung berulang).
+type KBestResult struct {
+	Paths     [][]Recipe
+	TreeSizes []int
+	Exhausted bool
+	Truncated bool
+}
+
+type treeChoice struct {
+	recipe Recipe
+	prev   *treeChoice
+}
+
+type ancestorChain struct {
+	element string
+	parent  *ancestorChain
+}
+
+func (a *ancestorChain) contains(element string) bool {
+	for node := a; node != nil; node = node.parent {
+		if node.element == element {
+			return true
+		}
+	}
+	return false
+}
+
+type openLeaf struct {
+	element   string
+	ancestors *ancestorChain
+}
+
+type partialTree struct {
+	size     int
+	estimate int
+	choices  *treeChoice
+	open     []openLeaf
+	seq      int
+}
+
+type partialTreeQueue []*partialTree
+
+func (q partialTreeQueue) Len() int { return len(q) }
+func (q partialTreeQueue) Less(i, j int) bool {
+	if q[i].estimate != q[j].estimate {
+		return q[i].estimate < q[j].estimate
+	}
+	// Pada estimasi yang sama, pohon yang lebih lengkap diteruskan dulu agar pohon besar cepat selesai
+	// alih-alih melebar ke semua cabang yang setara.
+	if q[i].size != q[j].size {
+		return q[i].size > q[j].size
+	}
+	return q[i].seq < q[j].seq
+}
+func (q partialTreeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *partialTreeQueue) Push(x any)   { *q = append(*q, x.(*partialTree)) }
+func (q *partialTreeQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// calculateMinTreeSizes menghitung ukuran pohon resep terkecil untuk setiap elemen (0 untuk elemen dasar).
+// Elemen yang tidak bisa dibuat tidak memiliki entri.
+func calculateMinTreeSizes(recipesByResult map[string][]Recipe) map[string]int {
+	sizes := make(map[string]int)
+	for _, base := range baseElements {
+		sizes[base] = 0
+	}
+	for changed := true; changed; {
+		changed = false
+		for result, recipes := range recipesByResult {
+			if isBaseElement(result) {
+				continue
+			}
+			for _, recipe := range recipes {
+				size1, ok1 := sizes[recipe.Ingredient1]
+				size2, ok2 := sizes[recipe.Ingredient2]
+				if !ok1 || !ok2 {
+					continue
+				}
+				candidate := 1 + size1 + size2
+				if current, exists := sizes[result]; !exists || candidate < current {
+					sizes[result] = candidate
+					changed = true
+				}
+			}
+		}
+	}
+	return sizes
+}
+
+// sortedRecipesFor mengurutkan resep secara deterministik: yang menjanjikan pohon terkecil lebih dulu.
+func sortedRecipesFor(element string, recipesByResult map[string][]Recipe, minSizes map[string]int) []Recipe {
+	var recipes []Recipe
+	for _, recipe := range recipesByResult[element] {
+		_, ok1 := minSizes[recipe.Ingredient1]
+		_, ok2 := minSizes[recipe.Ingredient2]
+		if ok1 && ok2 {
+			recipes = append(recipes, recipe)
+		}
+	}
+	sort.SliceStable(recipes, func(i, j int) bool {
+		costI := minSizes[recipes[i].Ingredient1] + minSizes[recipes[i].Ingredient2]
+		costJ := minSizes[recipes[j].Ingredient1] + minSizes[recipes[j].Ingredient2]
+		if costI != costJ {
+			return costI < costJ
+		}
+		return getRecipeID(recipes[i]) < getRecipeID(recipes[j])
+	})
+	return recipes
+}
+
+// FindKBestPaths mengenumerasi k pohon resep berbeda dengan ukuran tidak menurun (best-first dengan
+// batas bawah ukuran subpohon minimum yang admissible), lalu meratakannya menjadi langkah terurut.
+func FindKBestPaths(targetElement string, k int) (KBestResult, int, error) {
+	tracef("K-Best: Mencari %d pohon resep terbaik untuk: %s\n", k, targetElement)
+	recipesByResult := GetRecipeMap()
+	if recipesByResult == nil {
+		return KBestResult{}, 0, errors.New("map resep belum diinisialisasi")
+	}
+	if k <= 0 {
+		return KBestResult{}, 0, errors.New("jumlah resep minimal harus 1")
+	}
+	if isBaseElement(targetElement) {
+		return KBestResult{Paths: [][]Recipe{{}}, TreeSizes: []int{0}, Exhausted: k > 1}, 0, nil
+	}
+
+	minSizes := calculateMinTreeSizes(recipesByResult)
+	rootSize, reachable := minSizes[targetElement]
+	if !reachable {
+		return KBestResult{}, 0, fmt.Errorf("elemen '%s' tidak dapat dibuat dari elemen dasar", targetElement)
+	}
+
+	recipeCache := make(map[string][]Recipe)
+	recipesFor := func(element string) []Recipe {
+		if recipes, exists := recipeCache[element]; exists {
+			return recipes
+		}
+		recipes := sortedRecipesFor(element, recipesByResult, minSizes)
+		recipeCache[element] = recipes
+		return recipes
+	}
+
+	seq := 0
+	queue := &partialTreeQueue{}
+	heap.Push(queue, &partialTree{
+		estimate: rootSize,
+		open:     []openLeaf{{element: targetElement}},
+		seq:      seq,
+	})
+
+	result := KBestResult{}
+	seenPlans := make(map[string]bool)
+	nodesVisited := 0
+
+	for queue.Len() > 0 && len(result.Paths) < k {
+		if nodesVisited >= kBestMaxExpansions {
+			result.Truncated = true
+			break
+		}
+		state := heap.Pop(queue).(*partialTree)
+		nodesVisited++
+
+		if len(state.open) == 0 {
+			path := flattenTreeChoices(targetElement, state.choices)
+			planID := planIdentifier(path)
+			if seenPlans[planID] {
+				continue
+			}
+			seenPlans[planID] = true
+			result.Paths = append(result.Paths, path)
+			result.TreeSizes = append(result.TreeSizes, state.size)
+			tracef("K-Best: Pohon #%d ditemukan (ukuran %d, %d langkah)\n", len(result.Paths), state.size, len(path))
+			continue
+		}
+
+		leaf := state.open[len(state.open)-1]
+		rest := state.open[:len(state.open)-1]
+		ancestors := &ancestorChain{element: leaf.element, parent: leaf.ancestors}
+
+		for _, recipe := range recipesFor(leaf.element) {
+			if ancestors.contains(recipe.Ingredient1) || ancestors.contains(recipe.Ingredient2) {
+				continue
+			}
+			open := make([]openLeaf, len(rest), len(rest)+2)
+			copy(open, rest)
+			// Bahan kedua didorong lebih dulu agar bahan pertama diproses berikutnya (urutan preorder).
+			for _, ingredient := range []string{recipe.Ingredient2, recipe.Ingredient1} {
+				if !isBaseElement(ingredient) {
+					open = append(open, openLeaf{element: ingredient, ancestors: ancestors})
+				}
+			}
+			seq++
+			heap.Push(queue, &partialTree{
+				size:     state.size + 1,
+				estimate: state.estimate - minSizes[leaf.element] + 1 + minSizes[recipe.Ingredient1] + minSizes[recipe.Ingredient2],
+				choices:  &treeChoice{recipe: recipe, prev: state.choices},
+				open:     open,
+				seq:      seq,
+			})
+		}
+	}
+
+	result.Exhausted = !result.Truncated && len(result.Paths) < k
+	if result.Exhausted {
+		tracef("K-Best: Hanya ada %d pohon resep berbeda untuk '%s' (diminta %d).\n", len(result.Paths), targetElement, k)
+	}
+	if result.Truncated {
+		tracef("K-Best: Batas %d ekspansi tercapai, %d pohon ditemukan.\n", kBestMaxExpansions, len(result.Paths))
+	}
+	if len(result.Paths) == 0 {
+		return result, nodesVisited, fmt.Errorf("path to element '%s' not found", targetElement)
+	}
+	return result, nodesVisited, nil
+}
+
+// flattenTreeChoices membangun ulang pohon dari pilihan resep berurutan preorder, lalu menuliskannya
+// secara postorder sehingga setiap bahan sudah dibuat sebelum dipakai.
+func flattenTreeChoices(targetElement string, last *treeChoice) []Recipe {
+	var preorder []Recipe
+	for choice := last; choice != nil; choice = choice.prev {
+		preorder = append(preorder, choice.recipe)
+	}
+	for i, j := 0, len(preorder)-1; i < j; i, j = i+1, j-1 {
+		preorder[i], preorder[j] = preorder[j], preorder[i]
+	}
+
+	path := make([]Recipe, 0, len(preorder))
+	emitted := make(map[string]bool)
+	next := 0
+	var build func(element string)
+	build = func(element string) {
+		if isBaseElement(element) || next >= len(preorder) {
+			return
+		}
+		recipe := preorder[next]
+		next++
+		build(recipe.Ingredient1)
+		build(recipe.Ingredient2)
+		key := getUniqueRecipeKey(recipe)
+		if !emitted[key] {
+			emitted[key] = true
+			path = append(path, recipe)
+		}
+	}
+	build(targetElement)
+	return path
+}
+
+// planIdentifier berbeda dari generatePathIdentifier: dua resep berbeda untuk hasil yang sama tetap dibedakan.
+func planIdentifier(path []Recipe) string {
+	keys := make([]string, 0, len(path))
+	for _, recipe := range path {
+		keys = append(keys, getUniqueRecipeKey(recipe))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, "|")
+}
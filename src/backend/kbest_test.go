@@ -0,0 +1,58 @@
+// src/backend/kbest_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindKBestPathsOrderedDistinctAndDeterministic(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range []string{"Human", "Computer", "Picnic"} {
+		first, _, err := FindKBestPaths(element, 25)
+		if err != nil {
+			t.Fatalf("%s: %v", element, err)
+		}
+		if len(first.Paths) != 25 || first.Exhausted {
+			t.Fatalf("%s: diharapkan 25 pohon, didapat %d (exhausted=%t)", element, len(first.Paths), first.Exhausted)
+		}
+
+		seen := make(map[string]bool)
+		for i, path := range first.Paths {
+			if err := ValidatePath(element, path); err != nil {
+				t.Errorf("%s: pohon #%d: %v", element, i+1, err)
+			}
+			if i > 0 && first.TreeSizes[i] < first.TreeSizes[i-1] {
+				t.Errorf("%s: ukuran pohon #%d (%d) lebih kecil dari sebelumnya (%d)", element, i+1, first.TreeSizes[i], first.TreeSizes[i-1])
+			}
+			id := planIdentifier(path)
+			if seen[id] {
+				t.Errorf("%s: pohon #%d duplikat", element, i+1)
+			}
+			seen[id] = true
+		}
+
+		second, _, _ := FindKBestPaths(element, 25)
+		if !reflect.DeepEqual(first.Paths, second.Paths) {
+			t.Errorf("%s: hasil enumerasi tidak deterministik", element)
+		}
+	}
+}
+
+func TestFindKBestPathsReportsExhaustion(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	result, _, err := FindKBestPaths("Mud", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Exhausted || len(result.Paths) >= 10 {
+		t.Errorf("Mud seharusnya punya kurang dari 10 pohon dan exhausted, didapat %d (exhausted=%t)", len(result.Paths), result.Exhausted)
+	}
+	if result.TreeSizes[0] != calculateMinTreeSizes(GetRecipeMap())["Mud"] {
+		t.Errorf("pohon pertama harus berukuran minimum")
+	}
+}
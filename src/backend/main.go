@@ -6,36 +6,162 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"time"
 )
 
 func main() {
 	scrapeOnly := flag.Bool("scrapeonly", false, "Run scraping and filtering then exit")
+	maxRecipesLimit := flag.Int("maxrecipes", defaultSearchLimits.MaxRecipes, "Upper bound for the 'max' parameter of multiple-mode searches")
+	rateLimit := flag.Float64("ratelimit", defaultSearchLimits.RatePerSecond, "Search requests per second allowed for each client IP")
+	rateBurst := flag.Int("rateburst", defaultSearchLimits.Burst, "Burst size of the per-client search rate limiter")
+	maxSearchCost := flag.Int64("maxsearchcost", defaultSearchLimits.MaxConcurrentCost, "Total estimated cost of searches allowed to run concurrently")
+	searchQueueTimeout := flag.Duration("searchqueuetimeout", defaultSearchLimits.QueueTimeout, "How long a search waits for capacity before answering 429")
+	trustProxy := flag.Bool("trustproxy", defaultSearchLimits.TrustProxy, "Use X-Forwarded-For to identify clients (only behind a trusted proxy)")
+	validatePaths := flag.Bool("validatepaths", true, "Drop search results that are not craftable in order instead of returning them")
+	harnessOut := flag.String("harness", "", "Sweep every element with every algorithm, write the results to this CSV file and exit")
+	harnessBaseline := flag.String("harnessbaseline", "", "Baseline CSV from a previous -harness run; exit with an error on regressions")
+	harnessTimeFactor := flag.Float64("harnesstimefactor", 2.0, "Slowdown factor against the baseline reported as a regression (0 disables time checks)")
+	exportTarget := flag.String("export", "", "Render a plan for this element as DOT, Mermaid or SVG and exit")
+	exportFormat := flag.String("exportformat", "dot", "Export format: dot, mermaid or svg")
+	exportScope := flag.String("exportscope", "plan", "Export scope: plan (search result) or closure (every recipe below the element)")
+	exportAlgo := flag.String("exportalgo", "bfs", "Search algorithm used for -exportscope plan (see -algorithms)")
+	exportMax := flag.Int("exportmax", 1, "Number of plans to export; values above 1 use multiple mode")
+	exportImages := flag.Bool("exportimages", false, "Embed element images from data/image into SVG exports")
+	exportOut := flag.String("exportout", "", "File to write the export to (default stdout)")
+	exportGraphOut := flag.String("exportgraph", "", "Write the whole recipe graph to this file and exit")
+	exportGraphFormat := flag.String("exportgraphformat", "", "Graph export format: graphml, gexf or json (default from the file extension)")
+	diffOld := flag.String("diff", "", "Compare this recipe file against -diffnew, print the dataset diff and exit")
+	diffOldImages := flag.String("diffoldimages", "", "Image URL file belonging to the -diff snapshot (optional)")
+	diffNew := flag.String("diffnew", "", "Newer recipe file for -diff (default data/recipes_final_filtered.json)")
+	diffNewImages := flag.String("diffnewimages", "", "Image URL file belonging to -diffnew (default data/element_images_urls.json)")
+	diffFormat := flag.String("diffformat", "text", "Dataset diff output: text or json")
+	completionOut := flag.String("completion", "", "Plan a route that discovers every reachable element, write it to this JSON or CSV file and exit")
+	completionInventory := flag.String("completioninventory", "", "Comma-separated elements already discovered before the -completion route (base elements are always included)")
+	listAlgorithms := flag.Bool("algorithms", false, "List the registered search algorithms and exit")
+	datasetID := flag.String("dataset", "", "Serve a stored dataset version (ID or 'active') instead of scraping a new one")
+	adminToken := flag.String("admintoken", "", "Bearer token required by /api/versions/load and /rollback (default: localhost only)")
 	flag.Parse()
+	datasetAdminToken = *adminToken
+	enforcePathValidation = *validatePaths
 
-	RunScraping()
-	runFilter()
-	if *scrapeOnly {
-		log.Println("Scraping dan filtering selesai (mode scrapeonly). Aplikasi akan keluar.")
+	dataDirPath := "data"
+	if *listAlgorithms {
+		printAlgorithms()
 		return
 	}
-	log.Println("=== MEMULAI SERVER BACKEND ===")
-	dataDirPath := "data"
-	err := InitData(dataDirPath)
-	if err != nil {
-		log.Fatalf("FATAL: Gagal memuat data awal aplikasi dari '%s': %v", dataDirPath, err)
+	if *harnessOut != "" {
+		if err := runHarness(dataDirPath, *harnessOut, *harnessBaseline, *harnessTimeFactor); err != nil {
+			log.Fatalf("FATAL: Harness gagal: %v", err)
+		}
+		return
+	}
+	if *diffOld != "" {
+		if err := runDiffCommand(dataDirPath, *diffOld, *diffOldImages, *diffNew, *diffNewImages, *diffFormat); err != nil {
+			log.Fatalf("FATAL: Diff dataset gagal: %v", err)
+		}
+		return
+	}
+	if *exportGraphOut != "" {
+		if err := runGraphExportCommand(dataDirPath, *exportGraphOut, *exportGraphFormat); err != nil {
+			log.Fatalf("FATAL: Ekspor graf gagal: %v", err)
+		}
+		return
 	}
-	fmt.Println("Data awal berhasil dimuat.")
-	BuildGraph(GetRecipeMap())
-	fmt.Println("Struktur graf siap digunakan.")
+	if *completionOut != "" {
+		if err := runCompletionCommand(dataDirPath, *completionOut, *completionInventory); err != nil {
+			log.Fatalf("FATAL: Rute penyelesaian gagal: %v", err)
+		}
+		return
+	}
+	if *exportTarget != "" {
+		mode := "shortest"
+		if *exportMax > 1 {
+			mode = "multiple"
+		}
+		req := exportRequest{Target: *exportTarget, Format: *exportFormat, Scope: *exportScope, Algo: *exportAlgo, Mode: mode, Max: *exportMax, Images: *exportImages}
+		if err := runExportCommand(dataDirPath, req, *exportOut); err != nil {
+			log.Fatalf("FATAL: Ekspor gagal: %v", err)
+		}
+		return
+	}
+
+	if *datasetID != "" {
+		log.Println("=== MEMULAI SERVER BACKEND ===")
+		id, err := resolveStartupDatasetVersion(datasetVersionsDir, *datasetID)
+		if err != nil {
+			log.Fatalf("FATAL: %v", err)
+		}
+		if _, err := ActivateDatasetVersion(datasetVersionsDir, id); err != nil {
+			log.Fatalf("FATAL: Gagal memuat versi dataset '%s': %v", id, err)
+		}
+		fmt.Println("Data awal berhasil dimuat dari versi tersimpan.")
+	} else {
+		fetchedAt := time.Now()
+		RunScraping()
+		var stored *DatasetManifest
+		if filterResult, err := runFilter(); err == nil {
+			manifest, err := StoreDatasetVersion(datasetVersionsDir, filterResult, jsonFilePath, targetURL, fetchedAt)
+			if err != nil {
+				log.Printf("Peringatan: Gagal menyimpan versi dataset: %v", err)
+			} else {
+				stored = &manifest
+			}
+		}
+		if *scrapeOnly {
+			log.Println("Scraping dan filtering selesai (mode scrapeonly). Aplikasi akan keluar.")
+			return
+		}
+		log.Println("=== MEMULAI SERVER BACKEND ===")
+		if err := InitData(dataDirPath); err != nil {
+			log.Fatalf("FATAL: Gagal memuat data awal aplikasi dari '%s': %v", dataDirPath, err)
+		}
+		fmt.Println("Data awal berhasil dimuat.")
+		if stored != nil && stored.DatasetVersion == GetDatasetVersion() {
+			if err := recordActiveDatasetVersion(datasetVersionsDir, stored.ID); err != nil {
+				log.Printf("Peringatan: Gagal mencatat versi dataset aktif: %v", err)
+			}
+		}
+		BuildGraph(GetRecipeMap())
+		fmt.Println("Struktur graf siap digunakan.")
+	}
+
+	configureSearchLimits(searchLimitConfig{
+		MaxRecipes:        *maxRecipesLimit,
+		RatePerSecond:     *rateLimit,
+		Burst:             *rateBurst,
+		MaxConcurrentCost: *maxSearchCost,
+		QueueTimeout:      *searchQueueTimeout,
+		TrustProxy:        *trustProxy,
+	})
 
 	// Setup Rute API
-	http.HandleFunc("/api/search", searchHandler)
+	http.HandleFunc("/api/search", withRateLimit(searchHandler))
+	http.HandleFunc("/api/algorithms", algorithmsHandler)
+	http.HandleFunc("/api/search/batch", batchSearchHandler)
+	http.HandleFunc("/api/compare", withRateLimit(compareHandler))
+	http.HandleFunc("/api/count", withRateLimit(countHandler))
+	http.HandleFunc("/api/combine", withRateLimit(combineHandler))
+	http.HandleFunc("/api/completion", withRateLimit(completionHandler))
+	http.HandleFunc("/api/hint", withRateLimit(hintHandler))
+	http.HandleFunc("/api/game/sessions", withRateLimit(gameSessionsHandler))
+	http.HandleFunc("/api/game/combine", withRateLimit(gameCombineHandler))
+	http.HandleFunc("/api/game/undo", withRateLimit(gameUndoHandler))
+	http.HandleFunc("/api/game/discoveries", withRateLimit(gameDiscoveriesHandler))
+	http.HandleFunc("/api/game/load", withRateLimit(gameLoadHandler))
+	http.HandleFunc("/api/export", withRateLimit(exportHandler))
+	http.HandleFunc("/api/graph/export", withRateLimit(graphExportHandler))
+	http.HandleFunc("/api/analytics", withRateLimit(analyticsHandler))
+	http.HandleFunc("/api/impact", withRateLimit(impactHandler))
+	http.HandleFunc("/api/diff", withRateLimit(diffHandler))
+	http.HandleFunc("/api/versions", datasetVersionsHandler)
+	http.HandleFunc("/api/versions/load", datasetVersionActionHandler(ActivateDatasetVersion, true))
+	http.HandleFunc("/api/versions/rollback", datasetVersionActionHandler(RollbackDatasetVersion, false))
 
 	// Jalankan Server
 	port := "8080"
 	log.Printf("Server backend berjalan di http://localhost:%s\n", port)
 	log.Printf("Server frontend berjalan di http://localhost:3000\n")
-	err = http.ListenAndServe(":"+port, nil)
+	err := http.ListenAndServe(":"+port, nil)
 	if err != nil {
 		log.Fatalf("FATAL: Gagal menjalankan server: %v", err)
 	}
@@ -0,0 +1,305 @@
+// src/backend/ratelimit.go
+package main
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type searchLimitConfig struct {
+	MaxRecipes        int
+	RatePerSecond     float64
+	Burst             int
+	MaxConcurrentCost int64
+	QueueTimeout      time.Duration
+	TrustProxy        bool
+}
+
+var defaultSearchLimits = searchLimitConfig{
+	MaxRecipes:        100,
+	RatePerSecond:     5,
+	Burst:             20,
+	MaxConcurrentCost: 256,
+	QueueTimeout:      10 * time.Second,
+	TrustProxy:        false,
+}
+
+var (
+	searchLimits      = defaultSearchLimits
+	searchRateLimiter = newClientRateLimiter(defaultSearchLimits.RatePerSecond, defaultSearchLimits.Burst)
+	searchSemaphore   = newWeightedSemaphore(defaultSearchLimits.MaxConcurrentCost)
+)
+
+func configureSearchLimits(cfg searchLimitConfig) {
+	if cfg.MaxRecipes <= 0 {
+		cfg.MaxRecipes = defaultSearchLimits.MaxRecipes
+	}
+	if cfg.RatePerSecond <= 0 {
+		cfg.RatePerSecond = defaultSearchLimits.RatePerSecond
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = defaultSearchLimits.Burst
+	}
+	if cfg.MaxConcurrentCost <= 0 {
+		cfg.MaxConcurrentCost = defaultSearchLimits.MaxConcurrentCost
+	}
+	if cfg.QueueTimeout <= 0 {
+		cfg.QueueTimeout = defaultSearchLimits.QueueTimeout
+	}
+	searchLimits = cfg
+	searchRateLimiter = newClientRateLimiter(cfg.RatePerSecond, cfg.Burst)
+	searchSemaphore = newWeightedSemaphore(cfg.MaxConcurrentCost)
+	log.Printf("Batas pencarian: max=%d, rate=%.2f/detik, burst=%d, kapasitas biaya=%d, antrean=%v\n",
+		cfg.MaxRecipes, cfg.RatePerSecond, cfg.Burst, cfg.MaxConcurrentCost, cfg.QueueTimeout)
+}
+
+// Estimasi biaya ditentukan oleh masing-masing algoritma (lihat Searcher.EstimateCost) setelah opsi
+// pencarian diterapkan, karena opsi seperti lebar beam dan batas waktu ikut menentukan biayanya.
+func estimateSearchCost(algo, mode string, maxRecipes int, options SearchOptions) int64 {
+	if searcher, ok := LookupSearcher(algo); ok {
+		return withSearchOptions(searcher, options).EstimateCost(mode, maxRecipes)
+	}
+	if mode != "multiple" || maxRecipes <= 1 {
+		return 1
+	}
+	return int64(maxRecipes)
+}
+
+// datasetPassCost adalah biaya kapasitas untuk endpoint yang menjalankan filter dan perhitungan tier atas
+// seluruh dataset (analisis dampak dan diff dataset), setara pencarian multiple berukuran sedang.
+const datasetPassCost int64 = 16
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+type clientRateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	clients     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+func newClientRateLimiter(ratePerSecond float64, burst int) *clientRateLimiter {
+	return &clientRateLimiter{
+		rate:        ratePerSecond,
+		burst:       float64(burst),
+		clients:     make(map[string]*tokenBucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+// allow mengambil satu token untuk client. Jika kosong, dikembalikan lama tunggu sampai token berikutnya.
+func (l *clientRateLimiter) allow(client string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastCleanup) > time.Minute {
+		l.cleanup(now)
+	}
+
+	bucket, exists := l.clients[client]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.burst, lastSeen: now}
+		l.clients[client] = bucket
+	} else {
+		elapsed := now.Sub(bucket.lastSeen).Seconds()
+		bucket.tokens = math.Min(l.burst, bucket.tokens+elapsed*l.rate)
+		bucket.lastSeen = now
+	}
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - bucket.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// wait menunggu sampai client mendapat satu token atau ctx berakhir. Dipakai untuk membebankan batas laju
+// per item batch, bukan per permintaan HTTP.
+func (l *clientRateLimiter) wait(ctx context.Context, client string) error {
+	for {
+		allowed, wait := l.allow(client, time.Now())
+		if allowed {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Bucket yang sudah penuh kembali tidak perlu disimpan lagi.
+func (l *clientRateLimiter) cleanup(now time.Time) {
+	for client, bucket := range l.clients {
+		if bucket.tokens+now.Sub(bucket.lastSeen).Seconds()*l.rate >= l.burst {
+			delete(l.clients, client)
+		}
+	}
+	l.lastCleanup = now
+}
+
+type semaphoreWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+// weightedSemaphore melayani permintaan secara FIFO agar pencarian besar tidak kelaparan.
+type weightedSemaphore struct {
+	mu      sync.Mutex
+	size    int64
+	cur     int64
+	waiters list.List
+}
+
+func newWeightedSemaphore(size int64) *weightedSemaphore {
+	return &weightedSemaphore{size: size}
+}
+
+// Biaya yang melebihi kapasitas dipotong, sehingga pencarian sangat besar tetap bisa berjalan sendirian.
+func (s *weightedSemaphore) clamp(n int64) int64 {
+	if n > s.size {
+		return s.size
+	}
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+func (s *weightedSemaphore) acquire(ctx context.Context, n int64) (int64, error) {
+	n = s.clamp(n)
+	s.mu.Lock()
+	if s.size-s.cur >= n && s.waiters.Len() == 0 {
+		s.cur += n
+		s.mu.Unlock()
+		return n, nil
+	}
+
+	ready := make(chan struct{})
+	elem := s.waiters.PushBack(semaphoreWaiter{n: n, ready: ready})
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return n, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// Sudah mendapat jatah tepat saat dibatalkan, kembalikan.
+			s.cur -= n
+			s.notifyWaiters()
+		default:
+			isFront := s.waiters.Front() == elem
+			s.waiters.Remove(elem)
+			if isFront && s.size > s.cur {
+				s.notifyWaiters()
+			}
+		}
+		s.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
+
+func (s *weightedSemaphore) release(n int64) {
+	s.mu.Lock()
+	s.cur -= n
+	if s.cur < 0 {
+		s.mu.Unlock()
+		panic("weightedSemaphore: release melebihi acquire")
+	}
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+func (s *weightedSemaphore) notifyWaiters() {
+	for {
+		next := s.waiters.Front()
+		if next == nil {
+			break
+		}
+		w := next.Value.(semaphoreWaiter)
+		if s.size-s.cur < w.n {
+			break
+		}
+		s.cur += w.n
+		s.waiters.Remove(next)
+		close(w.ready)
+	}
+}
+
+func clientIP(r *http.Request) string {
+	if searchLimits.TrustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			first := strings.TrimSpace(strings.Split(forwarded, ",")[0])
+			if first != "" {
+				return first
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func writeTooManyRequests(w http.ResponseWriter, retryAfter time.Duration, message string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Expose-Headers", "Retry-After")
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	http.Error(w, message, http.StatusTooManyRequests)
+}
+
+func withRateLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next(w, r)
+			return
+		}
+		client := clientIP(r)
+		allowed, wait := searchRateLimiter.allow(client, time.Now())
+		if !allowed {
+			log.Printf("Rate limit: permintaan dari %s ditolak, coba lagi dalam %v\n", client, wait)
+			writeTooManyRequests(w, wait, "Terlalu banyak permintaan, silakan coba lagi nanti")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// acquireSearchSlot menunggu kapasitas pencarian. Jika gagal, respons 429 sudah ditulis dan ok bernilai false.
+func acquireSearchSlot(w http.ResponseWriter, r *http.Request, cost int64) (release func(), ok bool) {
+	ctx, cancel := context.WithTimeout(r.Context(), searchLimits.QueueTimeout)
+	defer cancel()
+
+	granted, err := searchSemaphore.acquire(ctx, cost)
+	if err != nil {
+		log.Printf("Kapasitas pencarian penuh: biaya=%d ditolak setelah menunggu %v\n", cost, searchLimits.QueueTimeout)
+		writeTooManyRequests(w, searchLimits.QueueTimeout,
+			fmt.Sprintf("Server sedang sibuk (biaya pencarian %d), silakan coba lagi nanti", cost))
+		return nil, false
+	}
+	return func() { searchSemaphore.release(granted) }, true
+}
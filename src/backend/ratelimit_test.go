@@ -0,0 +1,177 @@
+// src/backend/ratelimit_test.go
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientRateLimiterRefillsTokens(t *testing.T) {
+	start := time.Now()
+	limiter := newClientRateLimiter(2, 2)
+
+	steps := []struct {
+		client  string
+		offset  time.Duration
+		allowed bool
+		wait    time.Duration
+	}{
+		{"a", 0, true, 0},
+		{"a", 0, true, 0},
+		{"a", 0, false, 500 * time.Millisecond},
+		{"b", 0, true, 0},
+		{"a", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"a", 500 * time.Millisecond, true, 0},
+		{"a", 500 * time.Millisecond, false, 500 * time.Millisecond},
+		// Token tidak pernah melebihi burst walaupun client lama tidak mengirim permintaan.
+		{"a", 10 * time.Second, true, 0},
+		{"a", 10 * time.Second, true, 0},
+		{"a", 10 * time.Second, false, 500 * time.Millisecond},
+	}
+	for i, step := range steps {
+		allowed, wait := limiter.allow(step.client, start.Add(step.offset))
+		if allowed != step.allowed || wait != step.wait {
+			t.Errorf("langkah %d (%s +%v): allowed=%v wait=%v, seharusnya %v %v",
+				i, step.client, step.offset, allowed, wait, step.allowed, step.wait)
+		}
+	}
+
+	// Cleanup berjalan setelah satu menit dan hanya menghapus bucket yang sudah penuh kembali.
+	limiter.allow("c", start.Add(2*time.Minute))
+	if _, exists := limiter.clients["a"]; exists || len(limiter.clients) != 1 {
+		t.Errorf("bucket yang sudah penuh seharusnya dihapus saat cleanup: %v", limiter.clients)
+	}
+}
+
+func TestRateLimitSetsRetryAfter(t *testing.T) {
+	previous := searchRateLimiter
+	searchRateLimiter = newClientRateLimiter(0.4, 1)
+	t.Cleanup(func() { searchRateLimiter = previous })
+
+	handler := withRateLimit(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	codes := []int{http.StatusOK, http.StatusTooManyRequests}
+	for i, want := range codes {
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest(http.MethodGet, "/api/count", nil))
+		if w.Code != want {
+			t.Fatalf("permintaan #%d: status %d, seharusnya %d", i+1, w.Code, want)
+		}
+		// Token berikutnya datang dalam 2,5 detik, dibulatkan ke atas menjadi 3.
+		if want == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "3" {
+			t.Errorf("Retry-After = %q, seharusnya 3", w.Header().Get("Retry-After"))
+		}
+	}
+}
+
+// waitForWaiters menunggu sampai antrean semaphore berisi n waiter.
+func waitForWaiters(t *testing.T, s *weightedSemaphore, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		length := s.waiters.Len()
+		s.mu.Unlock()
+		if length == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("antrean semaphore tidak mencapai %d waiter", n)
+}
+
+func acquireAsync(s *weightedSemaphore, ctx context.Context, n int64) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		_, err := s.acquire(ctx, n)
+		result <- err
+	}()
+	return result
+}
+
+func expectAcquired(t *testing.T, result <-chan error, label string) {
+	t.Helper()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("%s seharusnya mendapat jatah: %v", label, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("%s tidak pernah mendapat jatah", label)
+	}
+}
+
+func expectDrained(t *testing.T, s *weightedSemaphore) {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cur != 0 || s.waiters.Len() != 0 {
+		t.Errorf("semaphore seharusnya kosong: cur=%d, waiter=%d", s.cur, s.waiters.Len())
+	}
+}
+
+func TestWeightedSemaphoreCancelledWaiterKeepsFIFO(t *testing.T) {
+	s := newWeightedSemaphore(4)
+	held, err := s.acquire(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	front := acquireAsync(s, ctx, 2)
+	waitForWaiters(t, s, 1)
+	second := acquireAsync(s, context.Background(), 2)
+	waitForWaiters(t, s, 2)
+	third := acquireAsync(s, context.Background(), 1)
+	waitForWaiters(t, s, 3)
+
+	// Waiter terdepan dibatalkan saat kapasitas masih penuh; waiter berikutnya tetap menunggu gilirannya.
+	cancel()
+	if err := <-front; !errors.Is(err, context.Canceled) {
+		t.Fatalf("waiter yang dibatalkan seharusnya gagal dengan context.Canceled: %v", err)
+	}
+	waitForWaiters(t, s, 2)
+
+	s.release(held)
+	expectAcquired(t, second, "waiter kedua")
+	expectAcquired(t, third, "waiter ketiga")
+	s.release(2)
+	s.release(1)
+	expectDrained(t, s)
+}
+
+func TestWeightedSemaphoreReturnsSlotGrantedDuringCancel(t *testing.T) {
+	s := newWeightedSemaphore(2)
+	held, err := s.acquire(context.Background(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := acquireAsync(s, ctx, 2)
+	waitForWaiters(t, s, 1)
+	next := acquireAsync(s, context.Background(), 1)
+	waitForWaiters(t, s, 2)
+
+	// Batalkan lalu berikan jatah selagi lock dipegang, sehingga waiter yang dibatalkan menemukan ready
+	// sudah tertutup ketika akhirnya mendapat lock dan harus mengembalikan jatahnya.
+	s.mu.Lock()
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	s.cur -= held
+	s.notifyWaiters()
+	s.mu.Unlock()
+
+	if err := <-cancelled; err == nil {
+		// select memilih ready lebih dulu; jatahnya sah dan dikembalikan seperti biasa.
+		s.release(2)
+	} else if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error tidak terduga: %v", err)
+	}
+	expectAcquired(t, next, "waiter berikutnya")
+	s.release(1)
+	expectDrained(t, s)
+}
@@ -0,0 +1,265 @@
+// src/backend/search.go
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SearchResult adalah keluaran seragam semua algoritma. Pada mode shortest Paths berisi paling banyak satu jalur.
+type SearchResult struct {
+	Paths        [][]Recipe
+	NodesVisited int
+	Exhausted    bool
+	// Truncated berarti pencarian berhenti di batas internalnya sebelum jumlah jalur yang diminta terpenuhi;
+	// jalur lain mungkin masih ada.
+	Truncated bool
+	// Iterations diisi algoritma yang mencari dalam beberapa putaran (misalnya iddfs).
+	Iterations []SearchIteration
+	// Beam diisi beam search untuk melaporkan kualitas hasil anytime.
+	Beam *BeamReport
+}
+
+type SearchCapabilities struct {
+	Modes []string `json:"modes"`
+	// Optimal berarti mode shortest selalu mengembalikan rencana dengan jumlah langkah minimum.
+	Optimal bool `json:"optimal"`
+	// Deterministic berarti hasil selalu sama untuk dataset dan parameter yang sama.
+	Deterministic bool `json:"deterministic"`
+	// ReportsExhausted berarti mode multiple memberi tahu jika semua jalur yang ada sudah dikembalikan.
+	ReportsExhausted bool `json:"reportsExhausted"`
+}
+
+// Searcher adalah algoritma pencarian resep yang bisa dipilih lewat parameter 'algo'.
+type Searcher interface {
+	Name() string
+	Description() string
+	Capabilities() SearchCapabilities
+	Shortest(target string) (SearchResult, error)
+	Multiple(target string, maxRecipes int) (SearchResult, error)
+	// EstimateCost memperkirakan biaya untuk semaphore pencarian, kira-kira sebanding dengan jumlah goroutine.
+	EstimateCost(mode string, maxRecipes int) int64
+}
+
+// SearchOptions berisi parameter tambahan yang hanya dipakai sebagian algoritma; nilai nol berarti default.
+type SearchOptions struct {
+	BeamWidth  int
+	TimeBudget time.Duration
+	// PathCache mengganti cache jalur BFS bersama; nil berarti memakai cache bersama.
+	PathCache *bfsPathCache
+}
+
+// configurableSearcher adalah Searcher yang menerima SearchOptions, misalnya beam search.
+type configurableSearcher interface {
+	Searcher
+	WithOptions(options SearchOptions) Searcher
+	OptionParameters() []SearchParameter
+}
+
+// withSearchOptions menerapkan options jika algoritma mendukungnya; algoritma lain mengabaikannya.
+func withSearchOptions(s Searcher, options SearchOptions) Searcher {
+	if configurable, ok := s.(configurableSearcher); ok && options != (SearchOptions{}) {
+		return configurable.WithOptions(options)
+	}
+	return s
+}
+
+// parseSearchOptions membaca parameter beamWidth dan budgetMs dari query string.
+func parseSearchOptions(query url.Values) (SearchOptions, error) {
+	var options SearchOptions
+	if raw := strings.TrimSpace(query.Get("beamWidth")); raw != "" {
+		width, err := strconv.Atoi(raw)
+		if err != nil || width <= 0 || width > maxBeamWidth {
+			return options, fmt.Errorf("Parameter 'beamWidth' harus berupa angka antara 1 dan %d", maxBeamWidth)
+		}
+		options.BeamWidth = width
+	}
+	if raw := strings.TrimSpace(query.Get("budgetMs")); raw != "" {
+		millis, err := strconv.Atoi(raw)
+		if err != nil || millis <= 0 || int64(millis) > maxBeamBudget.Milliseconds() {
+			return options, fmt.Errorf("Parameter 'budgetMs' harus berupa angka antara 1 dan %d", maxBeamBudget.Milliseconds())
+		}
+		options.TimeBudget = time.Duration(millis) * time.Millisecond
+	}
+	return options, nil
+}
+
+// funcSearcher membungkus fungsi pencarian biasa menjadi Searcher.
+type funcSearcher struct {
+	name         string
+	description  string
+	capabilities SearchCapabilities
+	shortest     func(string) ([]Recipe, int, error)
+	// shortestResult dipakai menggantikan shortest jika algoritma perlu mengisi field SearchResult lainnya.
+	shortestResult func(string) (SearchResult, error)
+	multiple       func(string, int) (SearchResult, error)
+	cost           func(maxRecipes int) int64
+}
+
+func (s funcSearcher) Name() string                     { return s.name }
+func (s funcSearcher) Description() string              { return s.description }
+func (s funcSearcher) Capabilities() SearchCapabilities { return s.capabilities }
+
+func (s funcSearcher) Shortest(target string) (SearchResult, error) {
+	if s.shortestResult != nil {
+		return s.shortestResult(target)
+	}
+	path, nodesVisited, err := s.shortest(target)
+	result := SearchResult{NodesVisited: nodesVisited}
+	if path != nil {
+		result.Paths = [][]Recipe{path}
+	}
+	return result, err
+}
+
+func (s funcSearcher) Multiple(target string, maxRecipes int) (SearchResult, error) {
+	return s.multiple(target, maxRecipes)
+}
+
+func (s funcSearcher) EstimateCost(mode string, maxRecipes int) int64 {
+	if mode != "multiple" || maxRecipes <= 1 || s.cost == nil {
+		return 1
+	}
+	return s.cost(maxRecipes)
+}
+
+// multiplePathsFunc menyesuaikan fungsi FindMultiplePaths* ke bentuk yang dipakai funcSearcher.
+func multiplePathsFunc(find func(string, int) ([][]Recipe, int, error)) func(string, int) (SearchResult, error) {
+	return func(target string, maxRecipes int) (SearchResult, error) {
+		paths, nodesVisited, err := find(target, maxRecipes)
+		return SearchResult{Paths: paths, NodesVisited: nodesVisited}, err
+	}
+}
+
+var searchRegistry = struct {
+	sync.RWMutex
+	searchers map[string]Searcher
+}{searchers: make(map[string]Searcher)}
+
+// RegisterSearcher mendaftarkan algoritma; dipanggil dari init() di file algoritma masing-masing.
+func RegisterSearcher(s Searcher) {
+	searchRegistry.Lock()
+	defer searchRegistry.Unlock()
+	name := strings.ToLower(s.Name())
+	if _, exists := searchRegistry.searchers[name]; exists {
+		panic(fmt.Sprintf("algoritma pencarian '%s' didaftarkan dua kali", name))
+	}
+	searchRegistry.searchers[name] = s
+}
+
+func LookupSearcher(name string) (Searcher, bool) {
+	searchRegistry.RLock()
+	defer searchRegistry.RUnlock()
+	s, ok := searchRegistry.searchers[name]
+	return s, ok
+}
+
+// RegisteredSearchers mengembalikan semua algoritma terurut berdasarkan nama agar urutannya stabil.
+func RegisteredSearchers() []Searcher {
+	searchRegistry.RLock()
+	defer searchRegistry.RUnlock()
+	searchers := make([]Searcher, 0, len(searchRegistry.searchers))
+	for _, s := range searchRegistry.searchers {
+		searchers = append(searchers, s)
+	}
+	sort.Slice(searchers, func(i, j int) bool { return searchers[i].Name() < searchers[j].Name() })
+	return searchers
+}
+
+func registeredSearcherNames() []string {
+	searchers := RegisteredSearchers()
+	names := make([]string, len(searchers))
+	for i, s := range searchers {
+		names[i] = s.Name()
+	}
+	return names
+}
+
+func supportsMode(s Searcher, mode string) bool {
+	for _, supported := range s.Capabilities().Modes {
+		if supported == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// searchersForMode mengembalikan algoritma yang mendukung mode tertentu, dipakai compare dan harness.
+func searchersForMode(mode string) []Searcher {
+	var searchers []Searcher
+	for _, s := range RegisteredSearchers() {
+		if supportsMode(s, mode) {
+			searchers = append(searchers, s)
+		}
+	}
+	return searchers
+}
+
+type SearchParameter struct {
+	Name        string   `json:"name"`
+	Required    bool     `json:"required"`
+	Modes       []string `json:"modes,omitempty"`
+	Description string   `json:"description"`
+}
+
+type AlgorithmInfo struct {
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Capabilities SearchCapabilities `json:"capabilities"`
+	Parameters   []SearchParameter  `json:"parameters"`
+}
+
+// searchParametersFor menyusun parameter /api/search yang berlaku untuk algoritma dengan mode tertentu.
+func searchParametersFor(s Searcher) []SearchParameter {
+	modes := s.Capabilities().Modes
+	parameters := []SearchParameter{
+		{Name: "target", Required: true, Description: "Nama elemen yang dicari"},
+		{Name: "mode", Description: fmt.Sprintf("Mode pencarian: %s (default shortest)", strings.Join(modes, " atau "))},
+		{Name: "format", Description: "Bentuk hasil: steps (default) atau tree"},
+	}
+	if supportsMode(s, "multiple") {
+		parameters = append(parameters,
+			SearchParameter{Name: "max", Required: true, Modes: []string{"multiple"}, Description: fmt.Sprintf("Jumlah jalur maksimum (dibatasi %d)", searchLimits.MaxRecipes)},
+			SearchParameter{Name: "minDiversity", Modes: []string{"multiple"}, Description: "Jarak Jaccard minimum antar jalur, 0 sampai 1"},
+		)
+	}
+	if configurable, ok := s.(configurableSearcher); ok {
+		parameters = append(parameters, configurable.OptionParameters()...)
+	}
+	return parameters
+}
+
+func algorithmsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	searchers := RegisteredSearchers()
+	algorithms := make([]AlgorithmInfo, 0, len(searchers))
+	for _, s := range searchers {
+		algorithms = append(algorithms, AlgorithmInfo{
+			Name:         s.Name(),
+			Description:  s.Description(),
+			Capabilities: s.Capabilities(),
+			Parameters:   searchParametersFor(s),
+		})
+	}
+	writeJSON(w, algorithms)
+}
+
+// printAlgorithms dipakai flag -algorithms untuk mencetak daftar algoritma di CLI.
+func printAlgorithms() {
+	for _, s := range RegisteredSearchers() {
+		fmt.Printf("%-8s %-18s %s\n", s.Name(), strings.Join(s.Capabilities().Modes, ","), s.Description())
+	}
+}
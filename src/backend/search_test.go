@@ -0,0 +1,105 @@
+// src/backend/search_test.go
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRegistryListsEveryAlgorithm(t *testing.T) {
+	loadTestDataset(t)
+
+	names := registeredSearcherNames()
+	for _, want := range []string{"bds", "bfs", "dfs", "kbest"} {
+		if _, ok := LookupSearcher(want); !ok {
+			t.Errorf("algoritma %s tidak terdaftar (%v)", want, names)
+		}
+	}
+	for _, searcher := range RegisteredSearchers() {
+		if len(searcher.Capabilities().Modes) == 0 || searcher.Description() == "" {
+			t.Errorf("%s: mode atau deskripsi kosong", searcher.Name())
+		}
+		if searcher.EstimateCost("shortest", 50) != 1 {
+			t.Errorf("%s: biaya mode shortest seharusnya 1", searcher.Name())
+		}
+	}
+
+	err := validateSearchParams("Brick", "dijkstra", "shortest")
+	if err == nil || !strings.Contains(err.Error(), strings.Join(names, ", ")) {
+		t.Errorf("pesan error seharusnya menyebut algoritma yang terdaftar: %v", err)
+	}
+}
+
+func TestComparisonKeepsSharedBFSCache(t *testing.T) {
+	loadTestDataset(t)
+	ResetCaches()
+
+	if _, _, err := FindPathBFS("Brick"); err != nil {
+		t.Fatalf("BFS Brick: %v", err)
+	}
+	response := runComparison("Brick", "shortest", 1)
+	for _, result := range response.Results {
+		if result.Algorithm == "bfs" && result.NodesVisited == 0 {
+			t.Errorf("NodesVisited BFS pada perbandingan seharusnya tidak berasal dari cache")
+		}
+	}
+	if _, exists := getSharedBFSPathCache().get("Brick"); !exists {
+		t.Errorf("perbandingan seharusnya tidak mengosongkan cache BFS bersama")
+	}
+}
+
+func TestSummarizeComparisonGroupsEquivalentPlans(t *testing.T) {
+	mud := Recipe{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}
+	brick := Recipe{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"}
+	stone := Recipe{Result: "Stone", Ingredient1: "Earth", Ingredient2: "Fire"}
+	longer := []Recipe{mud, stone, {Result: "Brick", Ingredient1: "Mud", Ingredient2: "Stone"}}
+
+	summary := summarizeComparison([]MultiSearchResponse{
+		{Algorithm: "bfs", Mode: "shortest", PathFound: true, Path: []Recipe{mud, brick}, NodesVisited: 10},
+		// Urutan langkah berbeda tetapi resepnya sama, jadi rencananya setara dengan bfs.
+		{Algorithm: "dfs", Mode: "shortest", PathFound: true, Path: []Recipe{mud, {Result: "Brick", Ingredient1: "Fire", Ingredient2: "Mud"}}, NodesVisited: 4},
+		{Algorithm: "kbest", Mode: "shortest", PathFound: true, Path: longer, NodesVisited: 4},
+		{Algorithm: "astar", Mode: "shortest", PathFound: false, NodesVisited: 1, Error: "path not found"},
+	})
+
+	if !reflect.DeepEqual(summary.ShortestPath, []string{"bfs", "dfs"}) {
+		t.Errorf("ShortestPath = %v", summary.ShortestPath)
+	}
+	if !reflect.DeepEqual(summary.FewestNodes, []string{"dfs", "kbest"}) {
+		t.Errorf("FewestNodes = %v, algoritma yang gagal tidak boleh ikut", summary.FewestNodes)
+	}
+	if !reflect.DeepEqual(summary.EquivalentGroups, [][]string{{"bfs", "dfs"}, {"kbest"}}) || summary.AllEquivalent {
+		t.Errorf("EquivalentGroups = %v, AllEquivalent = %v", summary.EquivalentGroups, summary.AllEquivalent)
+	}
+	if len(summary.Algorithms) != 4 || summary.Algorithms[2].PathLength != 3 || summary.Algorithms[2].DistinctRecipes != 3 {
+		t.Errorf("ringkasan per algoritma salah: %+v", summary.Algorithms)
+	}
+}
+
+func TestMultipleBFSPathsUseDistinctCombinations(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, element := range benchmarkTargets {
+		paths, _, err := FindMultiplePathsBFS(element, 5)
+		if err != nil {
+			t.Fatalf("BFS multiple %s: %v", element, err)
+		}
+		combos := make(map[string]bool)
+		for i, path := range paths {
+			if err := ValidatePath(element, path); err != nil {
+				t.Errorf("BFS multiple %s jalur #%d: %v", element, i+1, err)
+			}
+			for _, recipe := range path {
+				if recipe.Result == element {
+					combos[getUniqueRecipeKey(recipe)] = true
+				}
+			}
+		}
+		// Setiap jalur memakai kombinasi bahan target yang berbeda.
+		if len(combos) != len(paths) {
+			t.Errorf("BFS multiple %s: %d jalur tetapi %d kombinasi target", element, len(paths), len(combos))
+		}
+	}
+}
@@ -0,0 +1,475 @@
+// src/backend/store.go
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Setiap hasil pipeline scraping + filter disimpan di data/versions/<id>/. ID diturunkan dari isi file
+// sehingga hasil yang sama tidak pernah disimpan dua kali dan isi versi tidak pernah ditimpa.
+var datasetVersionsDir = filepath.Join("data", "versions")
+
+const (
+	manifestFileName      = "manifest.json"
+	activeVersionFileName = "active.json"
+	maxActiveHistory      = 50
+)
+
+var datasetVersionFiles = []string{
+	"recipes_scraped.json",
+	"recipes_final_filtered.json",
+	"filter_audit.json",
+	"tiers.json",
+	"element_images_urls.json",
+}
+
+var datasetVersionIDPattern = regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+type DatasetCounts struct {
+	RawRecipes      int `json:"rawRecipes"`
+	FilteredRecipes int `json:"filteredRecipes"`
+	RemovedRecipes  int `json:"removedRecipes"`
+	Elements        int `json:"elements"`
+	RemovedElements int `json:"removedElements"`
+	Images          int `json:"images"`
+}
+
+type DatasetManifest struct {
+	ID             string            `json:"id"`
+	DatasetVersion string            `json:"datasetVersion"`
+	SourceURL      string            `json:"sourceURL"`
+	FetchedAt      time.Time         `json:"fetchedAt"`
+	StoredAt       time.Time         `json:"storedAt"`
+	Counts         DatasetCounts     `json:"counts"`
+	Files          map[string]string `json:"files"`
+}
+
+// activeDatasetState mencatat versi yang sedang dipakai dan versi-versi sebelumnya untuk rollback.
+type activeDatasetState struct {
+	Current string   `json:"current"`
+	History []string `json:"history"`
+}
+
+type DatasetVersionsResponse struct {
+	Active   string            `json:"active"`
+	History  []string          `json:"history"`
+	Versions []DatasetManifest `json:"versions"`
+}
+
+type datasetVersionRequest struct {
+	ID string `json:"id"`
+}
+
+// datasetAdminToken diisi dari flag -admintoken. Jika kosong, /api/versions/load dan /rollback hanya menerima
+// permintaan dari localhost; jika diisi, permintaan harus membawa header "Authorization: Bearer <token>".
+var datasetAdminToken string
+
+// activeDatasetMu menyerialkan pergantian versi aktif agar file active.json dan data di memori tetap sejalan.
+var activeDatasetMu sync.Mutex
+
+// StoreDatasetVersion menyimpan hasil filter beserta resep mentah dan manifest gambar sebagai versi baru.
+// Jika versi dengan isi yang sama sudah ada, manifest lamanya dikembalikan tanpa menulis apa pun.
+func StoreDatasetVersion(versionsDir string, result FilterResult, imagesPath, sourceURL string, fetchedAt time.Time) (DatasetManifest, error) {
+	imageBytes, err := os.ReadFile(imagesPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return DatasetManifest{}, fmt.Errorf("gagal membaca manifest gambar %s: %w", imagesPath, err)
+	}
+	imageCount := 0
+	if imageBytes != nil {
+		var images []ElementImage
+		if err := json.Unmarshal(imageBytes, &images); err != nil {
+			return DatasetManifest{}, fmt.Errorf("gagal unmarshal JSON gambar dari %s: %w", imagesPath, err)
+		}
+		imageCount = len(images)
+	} else {
+		imageBytes = []byte("[]")
+	}
+
+	contents := make(map[string][]byte, len(datasetVersionFiles))
+	for name, value := range map[string]any{
+		"recipes_scraped.json":        result.RawRecipes,
+		"recipes_final_filtered.json": result.Recipes,
+		"filter_audit.json":           result.Audit,
+		"tiers.json":                  result.Tiers,
+	} {
+		encoded, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return DatasetManifest{}, fmt.Errorf("gagal marshal %s: %w", name, err)
+		}
+		contents[name] = encoded
+	}
+	contents["element_images_urls.json"] = imageBytes
+
+	manifest := DatasetManifest{
+		DatasetVersion: computeDatasetVersion(result.Recipes),
+		SourceURL:      sourceURL,
+		FetchedAt:      fetchedAt.UTC(),
+		StoredAt:       time.Now().UTC(),
+		Counts: DatasetCounts{
+			RawRecipes:      len(result.RawRecipes),
+			FilteredRecipes: len(result.Recipes),
+			RemovedRecipes:  len(result.Audit),
+			Elements:        len(snapshotElements(result.Recipes)),
+			RemovedElements: len(result.RemovedElements),
+			Images:          imageCount,
+		},
+		Files: make(map[string]string, len(contents)),
+	}
+	idHash := sha256.New()
+	for _, name := range datasetVersionFiles {
+		fileHash := sha256.Sum256(contents[name])
+		manifest.Files[name] = hex.EncodeToString(fileHash[:])
+		fmt.Fprintf(idHash, "%s %s\n", name, manifest.Files[name])
+	}
+	manifest.ID = hex.EncodeToString(idHash.Sum(nil))[:16]
+
+	versionDir := filepath.Join(versionsDir, manifest.ID)
+	if existing, err := readDatasetManifest(versionsDir, manifest.ID); err == nil {
+		return existing, nil
+	}
+
+	if err := os.MkdirAll(versionsDir, os.ModePerm); err != nil {
+		return DatasetManifest{}, fmt.Errorf("gagal membuat direktori '%s': %w", versionsDir, err)
+	}
+	// Ditulis ke direktori sementara lalu di-rename agar versi yang setengah jadi tidak pernah terlihat.
+	tempDir, err := os.MkdirTemp(versionsDir, ".tmp-"+manifest.ID+"-")
+	if err != nil {
+		return DatasetManifest{}, fmt.Errorf("gagal membuat direktori sementara: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return DatasetManifest{}, fmt.Errorf("gagal marshal manifest: %w", err)
+	}
+	contents[manifestFileName] = manifestBytes
+	for name, data := range contents {
+		if err := os.WriteFile(filepath.Join(tempDir, name), data, 0444); err != nil {
+			return DatasetManifest{}, fmt.Errorf("gagal menulis %s: %w", name, err)
+		}
+	}
+	if err := os.Chmod(tempDir, 0755); err != nil {
+		return DatasetManifest{}, err
+	}
+	if err := os.Rename(tempDir, versionDir); err != nil {
+		// Proses lain mungkin baru saja menyimpan versi yang sama.
+		if existing, readErr := readDatasetManifest(versionsDir, manifest.ID); readErr == nil {
+			return existing, nil
+		}
+		return DatasetManifest{}, fmt.Errorf("gagal menyimpan versi %s: %w", manifest.ID, err)
+	}
+	log.Printf("Versi dataset %s disimpan ke %s (%d resep valid)\n", manifest.ID, versionDir, manifest.Counts.FilteredRecipes)
+	return manifest, nil
+}
+
+func validateDatasetVersionID(id string) error {
+	if !datasetVersionIDPattern.MatchString(id) {
+		return fmt.Errorf("ID versi dataset '%s' tidak valid", id)
+	}
+	return nil
+}
+
+func readDatasetManifest(versionsDir, id string) (DatasetManifest, error) {
+	if err := validateDatasetVersionID(id); err != nil {
+		return DatasetManifest{}, err
+	}
+	bytes, err := os.ReadFile(filepath.Join(versionsDir, id, manifestFileName))
+	if err != nil {
+		return DatasetManifest{}, fmt.Errorf("versi dataset '%s' tidak ditemukan: %w", id, err)
+	}
+	var manifest DatasetManifest
+	if err := json.Unmarshal(bytes, &manifest); err != nil {
+		return DatasetManifest{}, fmt.Errorf("manifest versi '%s' rusak: %w", id, err)
+	}
+	return manifest, nil
+}
+
+// ListDatasetVersions mengembalikan manifest semua versi yang tersimpan, terbaru lebih dulu.
+func ListDatasetVersions(versionsDir string) ([]DatasetManifest, error) {
+	entries, err := os.ReadDir(versionsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return []DatasetManifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca direktori versi '%s': %w", versionsDir, err)
+	}
+	manifests := []DatasetManifest{}
+	for _, entry := range entries {
+		if !entry.IsDir() || validateDatasetVersionID(entry.Name()) != nil {
+			continue
+		}
+		manifest, err := readDatasetManifest(versionsDir, entry.Name())
+		if err != nil {
+			log.Printf("Peringatan: %v\n", err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		if !manifests[i].StoredAt.Equal(manifests[j].StoredAt) {
+			return manifests[i].StoredAt.After(manifests[j].StoredAt)
+		}
+		return manifests[i].ID < manifests[j].ID
+	})
+	return manifests, nil
+}
+
+func readActiveDatasetState(versionsDir string) (activeDatasetState, error) {
+	bytes, err := os.ReadFile(filepath.Join(versionsDir, activeVersionFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return activeDatasetState{History: []string{}}, nil
+	}
+	if err != nil {
+		return activeDatasetState{}, err
+	}
+	var state activeDatasetState
+	if err := json.Unmarshal(bytes, &state); err != nil {
+		return activeDatasetState{}, fmt.Errorf("file %s rusak: %w", activeVersionFileName, err)
+	}
+	if state.History == nil {
+		state.History = []string{}
+	}
+	return state, nil
+}
+
+func writeActiveDatasetState(versionsDir string, state activeDatasetState) error {
+	if err := os.MkdirAll(versionsDir, os.ModePerm); err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempPath := filepath.Join(versionsDir, activeVersionFileName+".tmp")
+	if err := os.WriteFile(tempPath, bytes, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, filepath.Join(versionsDir, activeVersionFileName))
+}
+
+// loadDatasetVersionRecipes membaca resep terfilter sebuah versi dan memastikan isinya cocok dengan manifest.
+func loadDatasetVersionRecipes(versionsDir, id string) ([]Recipe, DatasetManifest, error) {
+	manifest, err := readDatasetManifest(versionsDir, id)
+	if err != nil {
+		return nil, DatasetManifest{}, err
+	}
+	path := filepath.Join(versionsDir, id, "recipes_final_filtered.json")
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, DatasetManifest{}, fmt.Errorf("gagal membaca file %s: %w", path, err)
+	}
+	if sum := sha256.Sum256(bytes); hex.EncodeToString(sum[:]) != manifest.Files["recipes_final_filtered.json"] {
+		return nil, DatasetManifest{}, fmt.Errorf("isi %s tidak cocok dengan manifest versi %s", path, id)
+	}
+	var recipes []Recipe
+	if err := json.Unmarshal(bytes, &recipes); err != nil {
+		return nil, DatasetManifest{}, fmt.Errorf("gagal unmarshal JSON resep dari %s: %w", path, err)
+	}
+	return recipes, manifest, nil
+}
+
+// ActivateDatasetVersion memuat versi id ke server dan mencatat versi sebelumnya di riwayat.
+func ActivateDatasetVersion(versionsDir, id string) (DatasetManifest, error) {
+	activeDatasetMu.Lock()
+	defer activeDatasetMu.Unlock()
+	return activateDatasetVersionLocked(versionsDir, id, true)
+}
+
+// RollbackDatasetVersion kembali ke versi aktif sebelumnya, atau ke versi id jika diberikan.
+func RollbackDatasetVersion(versionsDir, id string) (DatasetManifest, error) {
+	activeDatasetMu.Lock()
+	defer activeDatasetMu.Unlock()
+	if id != "" {
+		return activateDatasetVersionLocked(versionsDir, id, true)
+	}
+	state, err := readActiveDatasetState(versionsDir)
+	if err != nil {
+		return DatasetManifest{}, err
+	}
+	if len(state.History) == 0 {
+		return DatasetManifest{}, errors.New("tidak ada versi sebelumnya untuk rollback")
+	}
+	return activateDatasetVersionLocked(versionsDir, state.History[len(state.History)-1], false)
+}
+
+// activateDatasetVersionLocked: pushHistory=false dipakai rollback, yang justru mengambil entri terakhir riwayat.
+func activateDatasetVersionLocked(versionsDir, id string, pushHistory bool) (DatasetManifest, error) {
+	recipes, manifest, err := loadDatasetVersionRecipes(versionsDir, id)
+	if err != nil {
+		return DatasetManifest{}, err
+	}
+	state, err := readActiveDatasetState(versionsDir)
+	if err != nil {
+		return DatasetManifest{}, err
+	}
+	if pushHistory {
+		if state.Current != "" && state.Current != id {
+			state.History = append(state.History, state.Current)
+			if len(state.History) > maxActiveHistory {
+				state.History = state.History[len(state.History)-maxActiveHistory:]
+			}
+		}
+	} else if len(state.History) > 0 {
+		state.History = state.History[:len(state.History)-1]
+	}
+	state.Current = id
+	if err := writeActiveDatasetState(versionsDir, state); err != nil {
+		return DatasetManifest{}, fmt.Errorf("gagal menyimpan versi aktif: %w", err)
+	}
+
+	ReplaceDataset(recipes)
+	log.Printf("Versi dataset %s aktif (%d resep, dataset %s)\n", id, len(recipes), manifest.DatasetVersion)
+	return manifest, nil
+}
+
+// recordActiveDatasetVersion menandai versi yang dimuat tanpa melalui ActivateDatasetVersion (misalnya saat startup).
+func recordActiveDatasetVersion(versionsDir, id string) error {
+	activeDatasetMu.Lock()
+	defer activeDatasetMu.Unlock()
+	state, err := readActiveDatasetState(versionsDir)
+	if err != nil {
+		return err
+	}
+	if state.Current == id {
+		return nil
+	}
+	if state.Current != "" {
+		state.History = append(state.History, state.Current)
+		if len(state.History) > maxActiveHistory {
+			state.History = state.History[len(state.History)-maxActiveHistory:]
+		}
+	}
+	state.Current = id
+	return writeActiveDatasetState(versionsDir, state)
+}
+
+// resolveStartupDatasetVersion menerjemahkan nilai flag -dataset; "active" berarti versi aktif terakhir.
+func resolveStartupDatasetVersion(versionsDir, value string) (string, error) {
+	if value != "active" {
+		return value, validateDatasetVersionID(value)
+	}
+	state, err := readActiveDatasetState(versionsDir)
+	if err != nil {
+		return "", err
+	}
+	if state.Current == "" {
+		return "", errors.New("belum ada versi dataset yang aktif")
+	}
+	return state.Current, nil
+}
+
+// datasetVersionsHandler: GET /api/versions mendaftar versi yang tersimpan.
+func datasetVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+
+	manifests, err := ListDatasetVersions(datasetVersionsDir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	state, err := readActiveDatasetState(datasetVersionsDir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, DatasetVersionsResponse{Active: state.Current, History: state.History, Versions: manifests})
+}
+
+// authorizeDatasetAdmin memeriksa apakah permintaan boleh mengganti dataset yang dipakai server. Alamat
+// localhost diambil dari RemoteAddr, bukan X-Forwarded-For, agar tidak bisa dipalsukan lewat header.
+func authorizeDatasetAdmin(r *http.Request) error {
+	if datasetAdminToken != "" {
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(datasetAdminToken)) != 1 {
+			return errors.New("token admin tidak valid")
+		}
+		return nil
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.New("mengganti versi dataset hanya diizinkan dari localhost atau dengan -admintoken")
+	}
+	return nil
+}
+
+// checkDatasetAdminRequest menolak permintaan yang bisa dikirim halaman web lain tanpa preflight CORS:
+// body harus application/json dan header Origin, jika ada, harus berasal dari host server sendiri.
+func checkDatasetAdminRequest(r *http.Request) error {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return errors.New("Content-Type harus application/json")
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		parsed, err := url.Parse(origin)
+		if err != nil || parsed.Host != r.Host {
+			return fmt.Errorf("origin '%s' tidak diizinkan", origin)
+		}
+	}
+	return nil
+}
+
+// datasetVersionActionHandler membuat handler POST untuk memuat atau rollback versi dengan body {"id": "..."}.
+// Berbeda dengan endpoint lain, handler ini sengaja tidak mengirim header CORS sehingga browser hanya
+// mengizinkan pemanggilan dari origin yang sama.
+func datasetVersionActionHandler(action func(versionsDir, id string) (DatasetManifest, error), requireID bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Metode tidak diizinkan", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := authorizeDatasetAdmin(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err := checkDatasetAdminRequest(r); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		var req datasetVersionRequest
+		if r.ContentLength != 0 {
+			decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes))
+			if err := decoder.Decode(&req); err != nil {
+				http.Error(w, fmt.Sprintf("Body JSON tidak valid: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+		if requireID && req.ID == "" {
+			http.Error(w, "Field 'id' wajib diisi", http.StatusBadRequest)
+			return
+		}
+
+		manifest, err := action(datasetVersionsDir, req.ID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, manifest)
+	}
+}
@@ -0,0 +1,165 @@
+// src/backend/store_test.go
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreDatasetVersionIsContentAddressed(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+	versionsDir := t.TempDir()
+
+	recipes := flattenRecipeMap(GetRecipeMap())
+	result := filterRecipes(recipes)
+	if len(result.Recipes) != len(recipes) {
+		t.Fatalf("filter pada dataset yang sudah terfilter seharusnya tidak menghapus resep: %d -> %d", len(recipes), len(result.Recipes))
+	}
+
+	fetchedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	first, err := StoreDatasetVersion(versionsDir, result, filepath.Join(versionsDir, "tidak-ada.json"), targetURL, fetchedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.DatasetVersion != GetDatasetVersion() || first.Counts.FilteredRecipes != len(recipes) {
+		t.Errorf("manifest tidak sesuai dataset: %+v", first)
+	}
+	for _, name := range append(datasetVersionFiles, manifestFileName) {
+		if _, err := os.Stat(filepath.Join(versionsDir, first.ID, name)); err != nil {
+			t.Errorf("file %s tidak tersimpan: %v", name, err)
+		}
+	}
+
+	again, err := StoreDatasetVersion(versionsDir, filterRecipes(recipes), "", targetURL, fetchedAt.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != first.ID || !again.StoredAt.Equal(first.StoredAt) {
+		t.Errorf("isi yang sama seharusnya memakai versi yang sudah ada: %s vs %s", again.ID, first.ID)
+	}
+
+	reduced, err := StoreDatasetVersion(versionsDir, filterRecipes(recipes[1:]), "", targetURL, fetchedAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reduced.ID == first.ID {
+		t.Error("isi berbeda seharusnya menghasilkan ID versi berbeda")
+	}
+	manifests, err := ListDatasetVersions(versionsDir)
+	if err != nil || len(manifests) != 2 {
+		t.Fatalf("seharusnya ada 2 versi tersimpan: %d %v", len(manifests), err)
+	}
+}
+
+func TestActivateAndRollbackDatasetVersion(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+	versionsDir := t.TempDir()
+
+	recipes := flattenRecipeMap(GetRecipeMap())
+	originalVersion := GetDatasetVersion()
+	original, err := StoreDatasetVersion(versionsDir, filterRecipes(recipes), "", targetURL, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	withoutMud := make([]Recipe, 0, len(recipes))
+	for _, recipe := range recipes {
+		if recipe.Result != "Mud" {
+			withoutMud = append(withoutMud, recipe)
+		}
+	}
+	modified, err := StoreDatasetVersion(versionsDir, filterRecipes(withoutMud), "", targetURL, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ReplaceDataset(recipes) })
+
+	if err := recordActiveDatasetVersion(versionsDir, original.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ActivateDatasetVersion(versionsDir, modified.ID); err != nil {
+		t.Fatal(err)
+	}
+	if GetDatasetVersion() != modified.DatasetVersion || len(GetRecipeMap()["Mud"]) > 0 {
+		t.Fatalf("versi yang dimuat tidak aktif: %s", GetDatasetVersion())
+	}
+
+	if _, err := RollbackDatasetVersion(versionsDir, ""); err != nil {
+		t.Fatal(err)
+	}
+	if GetDatasetVersion() != originalVersion {
+		t.Errorf("rollback seharusnya mengembalikan dataset awal: %s", GetDatasetVersion())
+	}
+	state, err := readActiveDatasetState(versionsDir)
+	if err != nil || state.Current != original.ID || len(state.History) != 0 {
+		t.Errorf("status versi aktif salah setelah rollback: %+v %v", state, err)
+	}
+	if _, err := RollbackDatasetVersion(versionsDir, ""); err == nil {
+		t.Error("rollback tanpa riwayat seharusnya gagal")
+	}
+	if _, err := ActivateDatasetVersion(versionsDir, "../../etc"); err == nil {
+		t.Error("ID versi yang tidak valid seharusnya ditolak")
+	}
+}
+
+func TestDatasetVersionActionsRequireAdmin(t *testing.T) {
+	handler := datasetVersionActionHandler(func(versionsDir, id string) (DatasetManifest, error) {
+		return DatasetManifest{ID: id}, nil
+	}, false)
+	request := func(remoteAddr, authorization string) int {
+		r := httptest.NewRequest(http.MethodPost, "/api/versions/rollback", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-For", "127.0.0.1")
+		r.Header.Set("Content-Type", "application/json")
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w.Code
+	}
+
+	if code := request("203.0.113.7:5000", ""); code != http.StatusForbidden {
+		t.Errorf("permintaan dari luar localhost seharusnya ditolak, status %d", code)
+	}
+	if code := request("127.0.0.1:5000", ""); code != http.StatusOK {
+		t.Errorf("permintaan dari localhost seharusnya diterima, status %d", code)
+	}
+
+	// Halaman web lain di host yang sama tidak boleh mengganti dataset lewat POST tanpa preflight.
+	crossSite := func(contentType, origin string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/versions/rollback", strings.NewReader(`{"id":"abc"}`))
+		r.RemoteAddr = "127.0.0.1:5000"
+		r.Header.Set("Content-Type", contentType)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		return w
+	}
+	if w := crossSite("text/plain", ""); w.Code != http.StatusForbidden {
+		t.Errorf("body text/plain seharusnya ditolak, status %d", w.Code)
+	}
+	if w := crossSite("application/json", "http://evil.example"); w.Code != http.StatusForbidden {
+		t.Errorf("origin lain seharusnya ditolak, status %d", w.Code)
+	}
+	if w := crossSite("application/json; charset=utf-8", "http://example.com"); w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("origin yang sama seharusnya diterima tanpa header CORS, status %d", w.Code)
+	}
+
+	datasetAdminToken = "rahasia"
+	t.Cleanup(func() { datasetAdminToken = "" })
+	if code := request("127.0.0.1:5000", ""); code != http.StatusForbidden {
+		t.Errorf("tanpa token seharusnya ditolak saat -admintoken diisi, status %d", code)
+	}
+	if code := request("203.0.113.7:5000", "Bearer rahasia"); code != http.StatusOK {
+		t.Errorf("token yang benar seharusnya diterima, status %d", code)
+	}
+}
@@ -0,0 +1,47 @@
+// src/backend/trace.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Log pelacakan algoritma dan pemuatan data ditulis lewat tracef/traceln, bukan langsung ke stdout, supaya
+// perintah CLI yang menulis hasilnya ke stdout (harness, export, diff) bisa mematikannya tanpa mengganti
+// os.Stdout.
+var (
+	traceMu     sync.RWMutex
+	traceWriter io.Writer // nil berarti os.Stdout
+)
+
+func traceOutput() io.Writer {
+	traceMu.RLock()
+	defer traceMu.RUnlock()
+	if traceWriter == nil {
+		return os.Stdout
+	}
+	return traceWriter
+}
+
+func tracef(format string, args ...any) {
+	fmt.Fprintf(traceOutput(), format, args...)
+}
+
+func traceln(args ...any) {
+	fmt.Fprintln(traceOutput(), args...)
+}
+
+// silenceTrace membuang log pelacakan sampai fungsi yang dikembalikan dipanggil.
+func silenceTrace() (restore func()) {
+	traceMu.Lock()
+	previous := traceWriter
+	traceWriter = io.Discard
+	traceMu.Unlock()
+	return func() {
+		traceMu.Lock()
+		traceWriter = previous
+		traceMu.Unlock()
+	}
+}
@@ -0,0 +1,129 @@
+// src/backend/tree.go
+package main
+
+import (
+	"errors"
+	"strings"
+)
+
+// Simpul pohon resep dalam bentuk DAG: subpohon yang dipakai bersama hanya muncul sekali dan dirujuk lewat ID.
+type RecipeTreeNode struct {
+	ID          int     `json:"id"`
+	Element     string  `json:"element"`
+	IsBase      bool    `json:"isBase"`
+	Recipe      *Recipe `json:"recipe,omitempty"`
+	Children    []int   `json:"children,omitempty"`
+	Depth       int     `json:"depth"`
+	SubtreeSize int     `json:"subtreeSize"`
+}
+
+type RecipeTree struct {
+	Root  int              `json:"root"`
+	Nodes []RecipeTreeNode `json:"nodes"`
+}
+
+func normalizeResponseFormat(raw string) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "" {
+		format = "steps"
+	}
+	if format != "steps" && format != "tree" {
+		return "", errors.New("Parameter 'format' harus 'steps' atau 'tree'")
+	}
+	return format, nil
+}
+
+// recipeParentFromPath menurunkan data yang sama dengan yang dipakai buildRecipePath: resep pembuat tiap elemen
+// (produksi pertama pada jalur) dan kedalamannya (0 untuk elemen dasar, 1 + kedalaman bahan terdalam).
+func recipeParentFromPath(path []Recipe) (map[string]Recipe, map[string]int) {
+	recipeParent := make(map[string]Recipe, len(path))
+	depth := make(map[string]int, len(path)+len(baseElements))
+	for _, base := range baseElements {
+		depth[base] = 0
+	}
+	for _, recipe := range path {
+		if _, exists := recipeParent[recipe.Result]; exists || isBaseElement(recipe.Result) {
+			continue
+		}
+		recipeParent[recipe.Result] = recipe
+		depth[recipe.Result] = 1 + max(depth[recipe.Ingredient1], depth[recipe.Ingredient2])
+	}
+	return recipeParent, depth
+}
+
+// BuildRecipeTree mengubah jalur datar menjadi DAG resep yang berakar di target.
+func BuildRecipeTree(target string, path []Recipe) (RecipeTree, error) {
+	recipeParent, depth := recipeParentFromPath(path)
+	tree := RecipeTree{Nodes: []RecipeTreeNode{}}
+	nodeIDs := make(map[string]int)
+	subtreeElements := make(map[string]map[string]bool)
+
+	var visit func(element string) (int, error)
+	visit = func(element string) (int, error) {
+		if id, exists := nodeIDs[element]; exists {
+			return id, nil
+		}
+		id := len(tree.Nodes)
+		nodeIDs[element] = id
+		tree.Nodes = append(tree.Nodes, RecipeTreeNode{ID: id, Element: element, IsBase: isBaseElement(element)})
+		if isBaseElement(element) {
+			return id, nil
+		}
+
+		recipe, exists := recipeParent[element]
+		if !exists {
+			return 0, &PathValidationError{Target: target, Reason: "tidak ada resep untuk '" + element + "' pada jalur"}
+		}
+		elements := map[string]bool{element: true}
+		children := make([]int, 0, 2)
+		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+			childID, err := visit(ingredient)
+			if err != nil {
+				return 0, err
+			}
+			children = append(children, childID)
+			for sub := range subtreeElements[ingredient] {
+				elements[sub] = true
+			}
+		}
+		subtreeElements[element] = elements
+
+		node := &tree.Nodes[id]
+		node.Recipe = &recipe
+		node.Children = children
+		node.Depth = depth[element]
+		node.SubtreeSize = len(elements)
+		return id, nil
+	}
+
+	root, err := visit(target)
+	if err != nil {
+		return RecipeTree{}, err
+	}
+	tree.Root = root
+	return tree, nil
+}
+
+// attachRecipeTrees menambahkan bentuk pohon untuk setiap jalur pada respons pencarian.
+func attachRecipeTrees(response *MultiSearchResponse) error {
+	if !response.PathFound {
+		return nil
+	}
+	if response.Mode == "shortest" {
+		tree, err := BuildRecipeTree(response.SearchTarget, response.Path)
+		if err != nil {
+			return err
+		}
+		response.Tree = &tree
+		return nil
+	}
+	response.Trees = make([]RecipeTree, 0, len(response.Paths))
+	for _, path := range response.Paths {
+		tree, err := BuildRecipeTree(response.SearchTarget, path)
+		if err != nil {
+			return err
+		}
+		response.Trees = append(response.Trees, tree)
+	}
+	return nil
+}
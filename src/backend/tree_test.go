@@ -0,0 +1,51 @@
+// src/backend/tree_test.go
+package main
+
+import "testing"
+
+func TestBuildRecipeTreeMatchesFlatPath(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, searcher := range searchersForMode("shortest") {
+		algo := searcher.Name()
+		for _, element := range benchmarkTargets {
+			ResetCaches()
+			result, err := searcher.Shortest(element)
+			if err != nil || len(result.Paths) == 0 {
+				t.Fatalf("%s: %s: %v", algo, element, err)
+			}
+			path := result.Paths[0]
+			tree, err := BuildRecipeTree(element, path)
+			if err != nil {
+				t.Fatalf("%s: %s: %v", algo, element, err)
+			}
+
+			root := tree.Nodes[tree.Root]
+			if root.Element != element || root.SubtreeSize != len(path) {
+				t.Errorf("%s: akar %s berukuran %d, jalur datar %d langkah", algo, root.Element, root.SubtreeSize, len(path))
+			}
+			if root.Depth != pathDepth(path) {
+				t.Errorf("%s: %s: kedalaman akar %d, kedalaman jalur %d", algo, element, root.Depth, pathDepth(path))
+			}
+			seen := make(map[string]bool)
+			for _, node := range tree.Nodes {
+				if seen[node.Element] {
+					t.Errorf("%s: %s: elemen %s muncul lebih dari sekali", algo, element, node.Element)
+				}
+				seen[node.Element] = true
+				for _, child := range node.Children {
+					if tree.Nodes[child].Depth >= node.Depth {
+						t.Errorf("%s: %s: anak %s tidak lebih dangkal dari %s", algo, element, tree.Nodes[child].Element, node.Element)
+					}
+				}
+			}
+
+			recipeParent, depth := recipeParentFromPath(path)
+			rebuilt := buildRecipePath(recipeParent, element, depth)
+			if err := ValidatePath(element, rebuilt); err != nil || planIdentifier(rebuilt) != planIdentifier(path) {
+				t.Errorf("%s: %s: buildRecipePath tidak menghasilkan ulang rencana yang sama: %v", algo, element, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// src/backend/validate.go
+package main
+
+import (
+	"fmt"
+	"log"
+)
+
+// Jika aktif, runSearch membuang rencana yang tidak bisa dibuat berurutan sebelum dikirim ke client.
+var enforcePathValidation = true
+
+type PathValidationError struct {
+	Target string
+	Step   int
+	Reason string
+}
+
+func (e *PathValidationError) Error() string {
+	if e.Step > 0 {
+		return fmt.Sprintf("jalur ke '%s' tidak valid pada langkah %d: %s", e.Target, e.Step, e.Reason)
+	}
+	return fmt.Sprintf("jalur ke '%s' tidak valid: %s", e.Target, e.Reason)
+}
+
+// ValidatePath memeriksa bahwa path bisa dibuat berurutan dari elemen dasar dan diakhiri dengan target.
+func ValidatePath(target string, path []Recipe) error {
+	return validatePathAgainst(target, path, GetRecipeMap())
+}
+
+func validatePathAgainst(target string, path []Recipe, recipesByResult map[string][]Recipe) error {
+	if isBaseElement(target) {
+		if len(path) > 0 {
+			return &PathValidationError{Target: target, Reason: "elemen dasar tidak memerlukan langkah pembuatan"}
+		}
+		return nil
+	}
+	if len(path) == 0 {
+		return &PathValidationError{Target: target, Reason: "jalur kosong untuk elemen bukan dasar"}
+	}
+
+	available := make(map[string]bool, len(path)+len(baseElements))
+	for _, base := range baseElements {
+		available[base] = true
+	}
+
+	for i, recipe := range path {
+		if !recipeExists(recipe, recipesByResult) {
+			return &PathValidationError{Target: target, Step: i + 1,
+				Reason: fmt.Sprintf("resep %s tidak ada di data resep", getRecipeID(recipe))}
+		}
+		for _, ingredient := range []string{recipe.Ingredient1, recipe.Ingredient2} {
+			if !available[ingredient] {
+				return &PathValidationError{Target: target, Step: i + 1,
+					Reason: fmt.Sprintf("bahan '%s' belum tersedia untuk membuat '%s'", ingredient, recipe.Result)}
+			}
+		}
+		available[recipe.Result] = true
+	}
+
+	if last := path[len(path)-1]; last.Result != target {
+		return &PathValidationError{Target: target, Step: len(path),
+			Reason: fmt.Sprintf("langkah terakhir menghasilkan '%s', bukan target", last.Result)}
+	}
+	return nil
+}
+
+func recipeExists(recipe Recipe, recipesByResult map[string][]Recipe) bool {
+	id := getRecipeID(recipe)
+	for _, candidate := range recipesByResult[recipe.Result] {
+		if getRecipeID(candidate) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// filterValidPaths membuang rencana tidak valid dari hasil pencarian dan mencatat diagnosisnya.
+func filterValidPaths(target, algo string, paths [][]Recipe) [][]Recipe {
+	valid := make([][]Recipe, 0, len(paths))
+	for i, path := range paths {
+		if err := ValidatePath(target, path); err != nil {
+			log.Printf("Validasi: jalur #%d dari %s dibuang: %v\n", i+1, algo, err)
+			continue
+		}
+		valid = append(valid, path)
+	}
+	return valid
+}
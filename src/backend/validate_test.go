@@ -0,0 +1,138 @@
+// src/backend/validate_test.go
+package main
+
+import (
+	"sort"
+	"testing"
+	"testing/quick"
+)
+
+func sortedElementNames() []string {
+	names := make([]string, 0, len(GetAllElementNames()))
+	for name := range GetAllElementNames() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestValidatePathEveryElementEveryAlgorithm(t *testing.T) {
+	if testing.Short() {
+		t.Skip("menyapu seluruh elemen memakan waktu lama")
+	}
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, searcher := range searchersForMode("shortest") {
+		algo := searcher.Name()
+		for _, element := range sortedElementNames() {
+			ResetCaches()
+			result, err := searcher.Shortest(element)
+			if err != nil {
+				t.Errorf("%s: tidak ada jalur ke %s: %v", algo, element, err)
+				continue
+			}
+			var path []Recipe
+			if len(result.Paths) > 0 {
+				path = result.Paths[0]
+			}
+			if err := ValidatePath(element, path); err != nil {
+				t.Errorf("%s: %v", algo, err)
+			}
+		}
+	}
+}
+
+func TestValidatePathMultipleVariants(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+
+	for _, searcher := range searchersForMode("multiple") {
+		algo := searcher.Name()
+		for _, element := range []string{"Brick", "Human", "Computer", "Dinosaur"} {
+			ResetCaches()
+			result, err := searcher.Multiple(element, 3)
+			if err != nil {
+				t.Errorf("%s: tidak ada jalur ke %s: %v", algo, element, err)
+				continue
+			}
+			for i, path := range result.Paths {
+				if err := ValidatePath(element, path); err != nil {
+					t.Errorf("%s: jalur #%d: %v", algo, i+1, err)
+				}
+			}
+		}
+	}
+}
+
+func TestValidatePathRejectsBrokenPlans(t *testing.T) {
+	loadTestDataset(t)
+
+	cases := []struct {
+		name   string
+		target string
+		path   []Recipe
+	}{
+		{"jalur kosong", "Brick", nil},
+		{"elemen dasar dengan langkah", "Fire", []Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+		{"bahan belum dibuat", "Brick", []Recipe{{Result: "Brick", Ingredient1: "Mud", Ingredient2: "Fire"}}},
+		{"resep tidak dikenal", "Mud", []Recipe{{Result: "Mud", Ingredient1: "Fire", Ingredient2: "Fire"}}},
+		{"tidak berakhir di target", "Brick", []Recipe{{Result: "Mud", Ingredient1: "Water", Ingredient2: "Earth"}}},
+	}
+	for _, tc := range cases {
+		if err := ValidatePath(tc.target, tc.path); err == nil {
+			t.Errorf("%s: seharusnya tidak valid", tc.name)
+		}
+	}
+
+	if err := ValidatePath("Water", nil); err != nil {
+		t.Errorf("elemen dasar tanpa langkah seharusnya valid: %v", err)
+	}
+}
+
+// Properti: untuk elemen acak, jalur DFS valid, dan setiap awalan sebelum target dibuat tidak valid.
+func TestValidatePathPrefixProperty(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+	names := sortedElementNames()
+
+	property := func(elementSeed uint16, cutSeed uint16) bool {
+		element := names[int(elementSeed)%len(names)]
+		path, _, err := FindPathDFS(element)
+		if err != nil || ValidatePath(element, path) != nil {
+			return false
+		}
+		if len(path) == 0 {
+			return isBaseElement(element)
+		}
+		cut := int(cutSeed) % len(path)
+		return ValidatePath(element, path[:cut]) != nil
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 300}); err != nil {
+		t.Error(err)
+	}
+}
+
+// Properti: memindahkan langkah terakhir ke depan merusak urutan kecuali semua bahannya elemen dasar.
+func TestValidatePathOrderProperty(t *testing.T) {
+	loadTestDataset(t)
+	muteTrace(t)
+	names := sortedElementNames()
+
+	property := func(elementSeed uint16) bool {
+		element := names[int(elementSeed)%len(names)]
+		path, _, err := FindPathDFS(element)
+		if err != nil || len(path) < 2 {
+			return true
+		}
+		last := path[len(path)-1]
+		if isBaseElement(last.Ingredient1) && isBaseElement(last.Ingredient2) {
+			return true
+		}
+		reordered := append([]Recipe{last}, path[:len(path)-1]...)
+		return ValidatePath(element, reordered) != nil
+	}
+	if err := quick.Check(property, &quick.Config{MaxCount: 300}); err != nil {
+		t.Error(err)
+	}
+}